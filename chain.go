@@ -73,19 +73,28 @@ func NewChain(provider Provider, prompter PromptFormatter, opts ...ChainOption)
 }
 
 // Call formats the prompts with the input values, generates using the llm, and parses
-// the output from the llm with the output parser. This function should not be called
-// directly, use rather the Call or Run function if the prompt only requires one input value.
+// the output from the llm with the output parser. The prompt is sent as chat messages,
+// keeping the role of each message. This function should not be called directly, use
+// rather the Call or Run function if the prompt only requires one input value.
 func (c *chain) Call(ctx context.Context, values map[string]any, options ...ChainOption) (map[string]any, error) {
 	prompt, err := c.prompter.FormatPrompt(values)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := Call(ctx, c.provider, prompt.String(), chainToContentOptions(options...)...)
+	messages := ChatMessagesToMessages(prompt.Messages())
+
+	resp, err := c.provider.GenerateContent(ctx, messages, ChainToContentOptions(options...)...)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(resp.Choices) == 0 {
+		return nil, ErrEmptyResponseFromProvider
+	}
+
+	result := resp.Choices[0].Content
+
 	finalOutput, err := c.parser.Parse(result)
 	if err != nil {
 		return nil, err
@@ -295,7 +304,9 @@ func ChainWithMemory(memory Memory) ChainOption {
 	}
 }
 
-func chainToContentOptions(options ...ChainOption) []ContentOption {
+// ChainToContentOptions converts the chain options into content options, for chains calling
+// a provider directly. Streaming chunks are sent to the hooks if no streaming func is set.
+func ChainToContentOptions(options ...ChainOption) []ContentOption {
 	opts := &ChainOptions{}
 
 	for _, option := range options {
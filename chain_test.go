@@ -1,10 +1,14 @@
 package llm_test
 
 import (
+	"context"
+	"reflect"
 	"testing"
 
 	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/messageformatters"
 	"github.com/peterhellberg/llm/mock"
+	"github.com/peterhellberg/llm/prompts/chatprompt"
 )
 
 func TestNewChain(t *testing.T) {
@@ -13,3 +17,85 @@ func TestNewChain(t *testing.T) {
 
 	llm.NewChain(provider, template)
 }
+
+func TestChainCallSendsChatMessages(t *testing.T) {
+	var got []llm.Message
+
+	provider := mock.Provider{
+		GenerateContentFunc: func(_ context.Context, messages []llm.Message, _ ...llm.ContentOption) (*llm.ContentResponse, error) {
+			got = messages
+
+			return &llm.ContentResponse{
+				Choices: []*llm.ContentChoice{{Content: "Paris"}},
+			}, nil
+		},
+	}
+
+	template := chatprompt.NewTemplate([]llm.MessageFormatter{
+		messageformatters.NewSystem("You are a geography expert", nil),
+		messageformatters.NewAI("Ask me anything", nil),
+		messageformatters.NewHuman("What is the capital of {{.country}}?", []string{"country"}),
+	})
+
+	out, err := llm.ChainRun(context.Background(), llm.NewChain(provider, template), "France")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "Paris"; out != want {
+		t.Fatalf("out = %q, want %q", out, want)
+	}
+
+	want := []llm.Message{
+		llm.TextParts(llm.ChatMessageTypeSystem, "You are a geography expert"),
+		llm.TextParts(llm.ChatMessageTypeAI, "Ask me anything"),
+		llm.TextParts(llm.ChatMessageTypeHuman, "What is the capital of France?"),
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("messages = %#v, want %#v", got, want)
+	}
+}
+
+type partsPrompt struct{}
+
+func (partsPrompt) InputVariables() []string { return []string{"url"} }
+
+func (partsPrompt) FormatPrompt(values map[string]any) (llm.Prompt, error) {
+	return chatprompt.Value{
+		llm.HumanPartsChatMessage{Parts: []llm.ContentPart{
+			llm.TextPart("What is in this image?"),
+			llm.ImageURLPart(values["url"].(string)),
+		}},
+	}, nil
+}
+
+func TestChainCallSendsMessageParts(t *testing.T) {
+	var got []llm.Message
+
+	provider := mock.Provider{
+		GenerateContentFunc: func(_ context.Context, messages []llm.Message, _ ...llm.ContentOption) (*llm.ContentResponse, error) {
+			got = messages
+
+			return &llm.ContentResponse{
+				Choices: []*llm.ContentChoice{{Content: "A cat"}},
+			}, nil
+		},
+	}
+
+	if _, err := llm.ChainRun(context.Background(), llm.NewChain(provider, partsPrompt{}), "https://example.com/cat.png"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []llm.Message{{
+		Role: llm.ChatMessageTypeHuman,
+		Parts: []llm.ContentPart{
+			llm.TextPart("What is in this image?"),
+			llm.ImageURLPart("https://example.com/cat.png"),
+		},
+	}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("messages = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,271 @@
+package constitutionalchain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Chain = Chain{}
+
+const (
+	defaultInitialOutputKey = "initial_output"
+	defaultStepsKey         = "critiques_and_revisions"
+	noCritiqueNeeded        = "no critique needed"
+)
+
+const critiqueTemplate = `Below is a request and a response to it, followed by a critique request.
+
+Request:
+{{.input}}
+
+Response:
+{{.output}}
+
+Critique Request: {{.critique_request}}
+
+If the response fully satisfies the critique request, respond with only "No critique needed." Otherwise respond with a short critique of the response.
+
+Critique:`
+
+const revisionTemplate = `Below is a request and a response to it, followed by a critique of the response and a revision request.
+
+Request:
+{{.input}}
+
+Response:
+{{.output}}
+
+Critique Request: {{.critique_request}}
+
+Critique: {{.critique}}
+
+Revision Request: {{.revision_request}}
+
+Respond with only the revised response.
+
+Revision:`
+
+// Principle is a named principle the output is checked against.
+type Principle struct {
+	// Name is the name of the principle, e.g. "house-style".
+	Name string
+
+	// CritiqueRequest asks for a critique of the output, e.g.
+	// "Identify any ways the response does not follow our house style."
+	CritiqueRequest string
+
+	// RevisionRequest asks for a revision of the output, e.g.
+	// "Rewrite the response to follow our house style."
+	RevisionRequest string
+}
+
+// Step is the critique and revision of the output for a principle.
+type Step struct {
+	Principle string
+	Critique  string
+	Revision  string
+}
+
+// Chain calls the wrapped chain, and then critiques and revises its output once for each
+// principle, in order. The revision is skipped when the critique is "No critique needed".
+type Chain struct {
+	// Chain is the wrapped chain, it must return a single string output.
+	Chain llm.Chain
+
+	// CritiqueChain critiques the output, it is given the "input", "output" and
+	// "critique_request" values, and must return a single string output.
+	CritiqueChain llm.Chain
+
+	// RevisionChain revises the output, it is given the "input", "output", "critique_request",
+	// "critique" and "revision_request" values, and must return a single string output.
+	RevisionChain llm.Chain
+
+	// Principles are the principles the output is checked against, in order.
+	Principles []Principle
+
+	// ReturnIntermediateSteps makes the chain return the output of the wrapped chain in the
+	// "initial_output" key, and a []Step in the "critiques_and_revisions" key.
+	ReturnIntermediateSteps bool
+
+	// Hooks are called with the input and output values of each critique and revision.
+	Hooks llm.ChainHooks
+}
+
+// New creates a new constitutional chain that uses the provider to critique and revise the output of the chain.
+func New(provider llm.Provider, chain llm.Chain, principles []Principle, options ...func(*Chain)) Chain {
+	c := Chain{
+		Chain: chain,
+		CritiqueChain: llm.NewChain(provider, llm.GoTemplate(critiqueTemplate, []string{
+			"input", "output", "critique_request",
+		})),
+		RevisionChain: llm.NewChain(provider, llm.GoTemplate(revisionTemplate, []string{
+			"input", "output", "critique_request", "critique", "revision_request",
+		})),
+		Principles: principles,
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	return c
+}
+
+// Call calls the wrapped chain, and critiques and revises its output for each principle.
+func (c Chain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	keys := c.Chain.OutputKeys()
+
+	if len(keys) != 1 {
+		return nil, llm.ErrMultipleOutputsInRun
+	}
+
+	outputs, err := llm.ChainCall(ctx, c.Chain, values, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	output, ok := outputs[keys[0]].(string)
+	if !ok {
+		return nil, llm.ErrWrongOutputTypeInRun
+	}
+
+	var (
+		input = c.formatInput(values)
+		steps = make([]Step, 0, len(c.Principles))
+	)
+
+	initial := output
+
+	for _, p := range c.Principles {
+		critique, err := c.callStep(ctx, c.CritiqueChain, p.Name, "critique", map[string]any{
+			"input":            input,
+			"output":           output,
+			"critique_request": p.CritiqueRequest,
+		}, options...)
+		if err != nil {
+			return nil, fmt.Errorf("critique %q: %w", p.Name, err)
+		}
+
+		critique = parseCritique(critique)
+
+		step := Step{Principle: p.Name, Critique: critique}
+
+		if !strings.Contains(strings.ToLower(critique), noCritiqueNeeded) {
+			revision, err := c.callStep(ctx, c.RevisionChain, p.Name, "revision", map[string]any{
+				"input":            input,
+				"output":           output,
+				"critique_request": p.CritiqueRequest,
+				"critique":         critique,
+				"revision_request": p.RevisionRequest,
+			}, options...)
+			if err != nil {
+				return nil, fmt.Errorf("revision %q: %w", p.Name, err)
+			}
+
+			step.Revision = strings.TrimSpace(revision)
+			output = step.Revision
+		}
+
+		steps = append(steps, step)
+	}
+
+	result := map[string]any{
+		keys[0]: output,
+	}
+
+	if c.ReturnIntermediateSteps {
+		result[defaultInitialOutputKey] = initial
+		result[defaultStepsKey] = steps
+	}
+
+	return result, nil
+}
+
+// Memory returns empty memory.
+func (c Chain) Memory() llm.Memory {
+	return llm.EmptyMemory{}
+}
+
+// InputKeys returns the input keys of the wrapped chain, that are not loaded from its memory.
+func (c Chain) InputKeys() []string {
+	memoryKeys := c.Chain.Memory().Variables(context.Background())
+
+	var keys []string
+
+	for _, key := range c.Chain.InputKeys() {
+		if !slices.Contains(memoryKeys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// OutputKeys returns the output key of the wrapped chain, and "initial_output"
+// and "critiques_and_revisions" if ReturnIntermediateSteps is set.
+func (c Chain) OutputKeys() []string {
+	keys := append([]string{}, c.Chain.OutputKeys()...)
+
+	if c.ReturnIntermediateSteps {
+		keys = append(keys, defaultInitialOutputKey, defaultStepsKey)
+	}
+
+	return keys
+}
+
+// formatInput formats the input values of the wrapped chain for the critique and revision prompts.
+func (c Chain) formatInput(values map[string]any) string {
+	keys := c.InputKeys()
+
+	if len(keys) == 1 {
+		return fmt.Sprint(values[keys[0]])
+	}
+
+	lines := make([]string, len(keys))
+
+	for i, key := range keys {
+		lines[i] = fmt.Sprintf("%s: %v", key, values[key])
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// callStep calls the chain and returns its single string output. The step is reported to
+// the hooks, if any, with the name of the principle and the output in the given key.
+func (c Chain) callStep(ctx context.Context, chain llm.Chain, principle, key string,
+	inputs map[string]any, options ...llm.ChainOption,
+) (string, error) {
+	if c.Hooks != nil {
+		c.Hooks.ChainStart(ctx, inputs)
+	}
+
+	text, err := llm.ChainPredict(ctx, chain, inputs, options...)
+	if err != nil {
+		if c.Hooks != nil {
+			c.Hooks.ChainError(ctx, err)
+		}
+
+		return "", err
+	}
+
+	if c.Hooks != nil {
+		c.Hooks.ChainEnd(ctx, map[string]any{
+			"principle": principle,
+			key:         text,
+		})
+	}
+
+	return text, nil
+}
+
+// parseCritique returns the critique, without any revision the model may have added.
+func parseCritique(critique string) string {
+	critique, _, _ = strings.Cut(critique, "Revision Request:")
+	critique, _, _ = strings.Cut(critique, "Revision:")
+
+	return strings.TrimSpace(critique)
+}
@@ -0,0 +1,78 @@
+package constitutionalchain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+func TestChain(t *testing.T) {
+	var ended []map[string]any
+
+	answer := mock.TextChain([]string{"question"}, "text", func(map[string]any) (string, error) {
+		return "hey, ur order is late lol", nil
+	})
+
+	c := Chain{
+		Chain: answer,
+		CritiqueChain: mock.TextChain(nil, "text", func(values map[string]any) (string, error) {
+			if strings.Contains(values["critique_request"].(string), "polite") {
+				return "The response is rude.", nil
+			}
+
+			return "No critique needed.", nil
+		}),
+		RevisionChain: mock.TextChain(nil, "text", func(values map[string]any) (string, error) {
+			if got, want := values["input"], "Where is my order?"; got != want {
+				t.Fatalf("input = %q, want %q", got, want)
+			}
+
+			return " We are sorry, your order is delayed. ", nil
+		}),
+		Principles: []Principle{
+			{Name: "polite", CritiqueRequest: "Is the response polite?", RevisionRequest: "Make it polite."},
+			{Name: "safe", CritiqueRequest: "Is the response safe?", RevisionRequest: "Make it safe."},
+		},
+		ReturnIntermediateSteps: true,
+		Hooks: mock.Hooks{
+			ChainStartFunc: func(context.Context, map[string]any) {},
+			ChainEndFunc: func(_ context.Context, out map[string]any) {
+				ended = append(ended, out)
+			},
+		},
+	}
+
+	got, err := llm.ChainCall(context.Background(), c, map[string]any{"question": "Where is my order?"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := got["text"], "We are sorry, your order is delayed."; got != want {
+		t.Fatalf("text = %q, want %q", got, want)
+	}
+
+	if got, want := got["initial_output"], "hey, ur order is late lol"; got != want {
+		t.Fatalf("initial_output = %q, want %q", got, want)
+	}
+
+	steps := got["critiques_and_revisions"].([]Step)
+
+	if got, want := len(steps), 2; got != want {
+		t.Fatalf("len(steps) = %d, want %d", got, want)
+	}
+
+	if got, want := steps[1].Revision, ""; got != want {
+		t.Fatalf("steps[1].Revision = %q, want %q", got, want)
+	}
+
+	if got, want := len(ended), 3; got != want {
+		t.Fatalf("len(ended) = %d, want %d", got, want)
+	}
+
+	if got, want := ended[1]["revision"], " We are sorry, your order is delayed. "; got != want {
+		t.Fatalf("ended[1][revision] = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,232 @@
+package conversationalretrievalchain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Chain = Chain{}
+
+const (
+	defaultInputKey             = "question"
+	defaultOutputKey            = "text"
+	defaultChatHistoryKey       = "chat_history"
+	defaultDocumentsKey         = "input_documents"
+	defaultSourceDocumentKey    = "source_documents"
+	defaultGeneratedQuestionKey = "generated_question"
+)
+
+const condenseQuestionTemplate = `Given the following conversation and a follow up question, rephrase the follow up question to be a standalone question, in its original language.
+
+Chat History:
+{{.chat_history}}
+Follow Up Input: {{.question}}
+Standalone question:`
+
+// Chain used for question-answering against a retriever in a conversation.
+// The follow up question is first rewritten into a standalone question using
+// the chat history loaded from the memory, then the documents are retrieved
+// using the standalone question, and finally the documents and the standalone
+// question are given to the combine documents chain.
+//
+// The original question and the answer are saved to the memory, so that
+// the memory does not need to know about the other outputs of the chain.
+type Chain struct {
+	// Retriever used to retrieve the relevant documents.
+	Retriever llm.Retriever
+
+	// CombineDocumentsChain is given the documents in the "input_documents"
+	// key and the standalone question in the "question" key.
+	CombineDocumentsChain llm.Chain
+
+	// CondenseQuestionChain rewrites the follow up question into a standalone
+	// question. It is given the "chat_history" and "question" values, and
+	// must return a single string output.
+	CondenseQuestionChain llm.Chain
+
+	// InputKey is the input key to get the question from, by default "question".
+	InputKey string
+
+	// OutputKey is the key of the answer in the outputs of the
+	// combine documents chain, by default "text".
+	OutputKey string
+
+	// HumanPrefix and AIPrefix are used when the chat history is loaded
+	// from the memory as chat messages, by default "Human" and "AI".
+	HumanPrefix string
+	AIPrefix    string
+
+	// ReturnSourceDocuments makes the chain return the documents used by the
+	// combine documents chain in the "source_documents" key.
+	ReturnSourceDocuments bool
+
+	// ReturnGeneratedQuestion makes the chain return the standalone
+	// question in the "generated_question" key.
+	ReturnGeneratedQuestion bool
+
+	memory llm.Memory
+}
+
+// New creates a new conversational retrieval chain. The chat history is loaded from the memory,
+// which is typically a conversation.Buffer.
+func New(condenseQuestionChain, combineDocumentsChain llm.Chain, retriever llm.Retriever,
+	memory llm.Memory, options ...func(*Chain),
+) Chain {
+	if memory == nil {
+		memory = llm.EmptyMemory{}
+	}
+
+	c := Chain{
+		Retriever:             retriever,
+		CombineDocumentsChain: combineDocumentsChain,
+		CondenseQuestionChain: condenseQuestionChain,
+		InputKey:              defaultInputKey,
+		OutputKey:             defaultOutputKey,
+		HumanPrefix:           "Human",
+		AIPrefix:              "AI",
+		memory:                memory,
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	return c
+}
+
+// NewCondenseQuestionChain creates a chain that uses the provider
+// to rewrite a follow up question into a standalone question.
+func NewCondenseQuestionChain(provider llm.Provider, opts ...llm.ChainOption) llm.Chain {
+	return llm.NewChain(provider,
+		llm.GoTemplate(condenseQuestionTemplate, []string{
+			defaultChatHistoryKey,
+			defaultInputKey,
+		}),
+		opts...,
+	)
+}
+
+// Call rewrites the question using the chat history, gets relevant documents from the
+// retriever and gives them to the combine documents chain.
+func (c Chain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	question, ok := values[c.InputKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", llm.ErrInvalidInputValues, llm.ErrInputValuesWrongType)
+	}
+
+	history, err := c.chatHistory(ctx, values)
+	if err != nil {
+		return nil, err
+	}
+
+	standalone := question
+
+	if history != "" {
+		standalone, err = c.condense(ctx, history, question, options...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	docs, err := c.Retriever.RelevantDocuments(ctx, standalone)
+	if err != nil {
+		return nil, err
+	}
+
+	inputValues := make(map[string]any, len(values)+2)
+
+	for key, value := range values {
+		inputValues[key] = value
+	}
+
+	inputValues[defaultInputKey] = standalone
+	inputValues[defaultDocumentsKey] = docs
+
+	result, err := llm.ChainCall(ctx, c.CombineDocumentsChain, inputValues, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ReturnSourceDocuments {
+		result[defaultSourceDocumentKey] = docs
+	}
+
+	if c.ReturnGeneratedQuestion {
+		result[defaultGeneratedQuestionKey] = standalone
+	}
+
+	return result, nil
+}
+
+// Memory returns the memory, that only saves the original question and the answer.
+func (c Chain) Memory() llm.Memory {
+	return memory{
+		Memory:    c.memory,
+		inputKey:  c.InputKey,
+		outputKey: c.OutputKey,
+	}
+}
+
+// InputKeys returns the input key, by default "question".
+func (c Chain) InputKeys() []string {
+	return []string{c.InputKey}
+}
+
+// OutputKeys returns the output keys of the combine documents chain, and
+// "source_documents" and "generated_question" if they are to be returned.
+func (c Chain) OutputKeys() []string {
+	keys := append([]string{}, c.CombineDocumentsChain.OutputKeys()...)
+
+	if c.ReturnSourceDocuments {
+		keys = append(keys, defaultSourceDocumentKey)
+	}
+
+	if c.ReturnGeneratedQuestion {
+		keys = append(keys, defaultGeneratedQuestionKey)
+	}
+
+	return keys
+}
+
+// chatHistory returns the chat history loaded from the memory as a string.
+func (c Chain) chatHistory(ctx context.Context, values map[string]any) (string, error) {
+	switch history := values[c.memory.MemoryKey(ctx)].(type) {
+	case string:
+		return history, nil
+	case []llm.ChatMessage:
+		return llm.BufferString(history, c.HumanPrefix, c.AIPrefix)
+	default:
+		return "", nil
+	}
+}
+
+// condense rewrites the question into a standalone question.
+func (c Chain) condense(ctx context.Context, history, question string, options ...llm.ChainOption) (string, error) {
+	text, err := llm.ChainPredict(ctx, c.CondenseQuestionChain, map[string]any{
+		defaultChatHistoryKey: history,
+		defaultInputKey:       question,
+	}, options...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(text), nil
+}
+
+// memory saves only the question and the answer to the wrapped memory.
+type memory struct {
+	llm.Memory
+
+	inputKey  string
+	outputKey string
+}
+
+func (m memory) SaveContext(ctx context.Context, inputs map[string]any, outputs map[string]any) error {
+	return m.Memory.SaveContext(ctx,
+		map[string]any{m.inputKey: inputs[m.inputKey]},
+		map[string]any{m.outputKey: outputs[m.outputKey]},
+	)
+}
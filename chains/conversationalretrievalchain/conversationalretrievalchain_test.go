@@ -0,0 +1,78 @@
+package conversationalretrievalchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/memory/conversation"
+	"github.com/peterhellberg/llm/mock"
+)
+
+type retriever func(ctx context.Context, query string) ([]llm.Document, error)
+
+func (r retriever) RelevantDocuments(ctx context.Context, query string) ([]llm.Document, error) {
+	return r(ctx, query)
+}
+
+func TestChain(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		queries []string
+		memory  = conversation.NewBuffer()
+	)
+
+	condense := mock.TextChain([]string{"chat_history", "question"}, "text", func(values map[string]any) (string, error) {
+		return " What is the second planet? ", nil
+	})
+
+	combine := mock.TextChain([]string{"input_documents", "question"}, "text", func(values map[string]any) (string, error) {
+		return "Answer to " + values["question"].(string), nil
+	})
+
+	c := New(condense, combine, retriever(func(_ context.Context, query string) ([]llm.Document, error) {
+		queries = append(queries, query)
+
+		return []llm.Document{{PageContent: query}}, nil
+	}), memory, func(c *Chain) {
+		c.ReturnSourceDocuments = true
+		c.ReturnGeneratedQuestion = true
+	})
+
+	if _, err := llm.ChainCall(ctx, c, map[string]any{"question": "What are the planets?"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := llm.ChainCall(ctx, c, map[string]any{"question": "What about the second one?"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := queries[0], "What are the planets?"; got != want {
+		t.Fatalf("queries[0] = %q, want %q", got, want)
+	}
+
+	if got, want := queries[1], "What is the second planet?"; got != want {
+		t.Fatalf("queries[1] = %q, want %q", got, want)
+	}
+
+	if got, want := got["generated_question"], "What is the second planet?"; got != want {
+		t.Fatalf("generated_question = %q, want %q", got, want)
+	}
+
+	if got, want := len(got["source_documents"].([]llm.Document)), 1; got != want {
+		t.Fatalf("len(source_documents) = %d, want %d", got, want)
+	}
+
+	vars, err := memory.LoadVariables(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "Human: What are the planets?\nAI: Answer to What are the planets?\n" +
+		"Human: What about the second one?\nAI: Answer to What is the second planet?"
+
+	if got := vars["history"]; got != want {
+		t.Fatalf("history = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,335 @@
+package mapreducedocumentschain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Chain = Chain{}
+
+const (
+	defaultInputKey              = "input_documents"
+	defaultDocumentVariableName  = "context"
+	defaultIntermediateStepsKey  = "intermediate_steps"
+	defaultMaxConcurrency        = 4
+	defaultTokenMax              = 3000
+	defaultMaxCollapseIterations = 10
+)
+
+// ErrTokenMaxExceeded is returned when the documents can not be collapsed to fit within the token budget.
+var ErrTokenMaxExceeded = errors.New("documents can not be collapsed to fit within the token budget")
+
+// Chain that combines documents by first calling the map chain for each
+// document, then collapsing the results until they fit within the token
+// budget, and finally combining them using the reduce chain.
+//
+// The map chain is given all input values of the chain, and the content of
+// the document in the key specified by DocumentVariableName, by default
+// "context". The collapse and reduce chains are combine documents chains,
+// such as stuffdocumentschain.Chain, given all input values of the chain
+// with the mapped documents in the "input_documents" key.
+type Chain struct {
+	// MapChain is called for each document.
+	MapChain llm.Chain
+
+	// ReduceChain combines the mapped documents into the final output.
+	ReduceChain llm.Chain
+
+	// CollapseChain combines groups of mapped documents when they do not fit
+	// within TokenMax, by default the ReduceChain.
+	CollapseChain llm.Chain
+
+	// InputKey is the input key the documents are expected to be in,
+	// by default "input_documents".
+	InputKey string
+
+	// DocumentVariableName is the variable name used by the map
+	// chain for the content of the document, by default "context".
+	DocumentVariableName string
+
+	// MaxConcurrency is the maximum number of concurrent calls to
+	// the map and collapse chains, by default 4.
+	MaxConcurrency int
+
+	// TokenMax is the maximum number of tokens in the documents
+	// given to the reduce chain, by default 3000.
+	TokenMax int
+
+	// TokenCount returns the number of tokens in a text, by default
+	// estimated as one token per four characters.
+	TokenCount func(text string) int
+
+	// MaxCollapseIterations is the maximum number of times the
+	// documents are collapsed, by default 10.
+	MaxCollapseIterations int
+
+	// ReturnIntermediateSteps makes the chain return the outputs of the
+	// map chain as a []string in the "intermediate_steps" key.
+	ReturnIntermediateSteps bool
+}
+
+// New creates a new map-reduce documents chain.
+func New(mapChain, reduceChain llm.Chain, options ...func(*Chain)) Chain {
+	c := Chain{
+		MapChain:              mapChain,
+		ReduceChain:           reduceChain,
+		InputKey:              defaultInputKey,
+		DocumentVariableName:  defaultDocumentVariableName,
+		MaxConcurrency:        defaultMaxConcurrency,
+		TokenMax:              defaultTokenMax,
+		TokenCount:            estimateTokens,
+		MaxCollapseIterations: defaultMaxCollapseIterations,
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	return c
+}
+
+// Call maps each document, collapses the results if needed and reduces them into the final output.
+func (c Chain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	docs, ok := values[c.InputKey].([]llm.Document)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", llm.ErrInvalidInputValues, llm.ErrInputValuesWrongType)
+	}
+
+	mapped, err := c.mapDocuments(ctx, docs, values, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]string, len(mapped))
+
+	for i, doc := range mapped {
+		steps[i] = doc.PageContent
+	}
+
+	collapsed, err := c.collapse(ctx, mapped, values, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := llm.ChainCall(ctx, c.ReduceChain, withDocuments(values, c.InputKey, collapsed), options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ReturnIntermediateSteps {
+		result[defaultIntermediateStepsKey] = steps
+	}
+
+	return result, nil
+}
+
+// Memory returns empty memory.
+func (c Chain) Memory() llm.Memory {
+	return llm.EmptyMemory{}
+}
+
+// InputKeys returns the expected input keys, by default "input_documents".
+func (c Chain) InputKeys() []string {
+	return []string{c.InputKey}
+}
+
+// OutputKeys returns the output keys of the reduce chain, and
+// "intermediate_steps" if ReturnIntermediateSteps is set.
+func (c Chain) OutputKeys() []string {
+	keys := append([]string{}, c.ReduceChain.OutputKeys()...)
+
+	if c.ReturnIntermediateSteps {
+		keys = append(keys, defaultIntermediateStepsKey)
+	}
+
+	return keys
+}
+
+// mapDocuments calls the map chain for each document, with at most MaxConcurrency concurrent calls.
+func (c Chain) mapDocuments(ctx context.Context, docs []llm.Document, values map[string]any, options ...llm.ChainOption) ([]llm.Document, error) {
+	return c.concurrently(ctx, len(docs), func(ctx context.Context, i int) (llm.Document, error) {
+		inputs := withDocuments(values, c.InputKey, docs[i:i+1])
+		inputs[c.DocumentVariableName] = docs[i].PageContent
+
+		text, err := llm.ChainPredict(ctx, c.MapChain, inputs, options...)
+		if err != nil {
+			return llm.Document{}, err
+		}
+
+		return llm.Document{PageContent: text, Metadata: docs[i].Metadata}, nil
+	})
+}
+
+// collapse combines groups of documents using the collapse chain until they fit within TokenMax.
+func (c Chain) collapse(ctx context.Context, docs []llm.Document, values map[string]any, options ...llm.ChainOption) ([]llm.Document, error) {
+	chain := c.CollapseChain
+
+	if chain == nil {
+		chain = c.ReduceChain
+	}
+
+	for range c.MaxCollapseIterations {
+		if c.tokens(docs) <= c.TokenMax {
+			return docs, nil
+		}
+
+		groups, err := c.split(docs)
+		if err != nil {
+			return nil, err
+		}
+
+		docs, err = c.concurrently(ctx, len(groups), func(ctx context.Context, i int) (llm.Document, error) {
+			text, err := llm.ChainPredict(ctx, chain, withDocuments(values, c.InputKey, groups[i]), options...)
+			if err != nil {
+				return llm.Document{}, err
+			}
+
+			return llm.Document{PageContent: text, Metadata: mergeMetadata(groups[i])}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.tokens(docs) > c.TokenMax {
+		return nil, ErrTokenMaxExceeded
+	}
+
+	return docs, nil
+}
+
+// split splits the documents into groups that each fit within TokenMax.
+func (c Chain) split(docs []llm.Document) ([][]llm.Document, error) {
+	var (
+		groups [][]llm.Document
+		group  []llm.Document
+		tokens int
+	)
+
+	for _, doc := range docs {
+		n := c.TokenCount(doc.PageContent)
+
+		if n > c.TokenMax {
+			return nil, fmt.Errorf("%w: a single document has %d tokens", ErrTokenMaxExceeded, n)
+		}
+
+		if len(group) > 0 && tokens+n > c.TokenMax {
+			groups = append(groups, group)
+			group, tokens = nil, 0
+		}
+
+		group = append(group, doc)
+		tokens += n
+	}
+
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+func (c Chain) tokens(docs []llm.Document) int {
+	var n int
+
+	for _, doc := range docs {
+		n += c.TokenCount(doc.PageContent)
+	}
+
+	return n
+}
+
+// concurrently calls fn for each index, with at most MaxConcurrency concurrent calls.
+// The first error cancels the remaining calls.
+func (c Chain) concurrently(ctx context.Context, n int, fn func(context.Context, int) (llm.Document, error)) ([]llm.Document, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		results  = make([]llm.Document, n)
+		sem      = make(chan struct{}, max(1, c.MaxConcurrency))
+	)
+
+	for i := range n {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			doc, err := fn(ctx, i)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+
+				return
+			}
+
+			results[i] = doc
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// withDocuments returns a copy of the values with the documents in the given key.
+func withDocuments(values map[string]any, key string, docs []llm.Document) map[string]any {
+	inputs := make(map[string]any, len(values))
+
+	for k, v := range values {
+		inputs[k] = v
+	}
+
+	inputs[key] = docs
+
+	return inputs
+}
+
+// mergeMetadata merges the metadata of the documents, where later documents take precedence.
+func mergeMetadata(docs []llm.Document) map[string]any {
+	metadata := map[string]any{}
+
+	for _, doc := range docs {
+		for k, v := range doc.Metadata {
+			metadata[k] = v
+		}
+	}
+
+	return metadata
+}
+
+// estimateTokens estimates the number of tokens as one token per four characters.
+func estimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
@@ -0,0 +1,119 @@
+package mapreducedocumentschain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+// joinChain joins the page content of the input documents with "+".
+var joinChain = mock.TextChain([]string{"input_documents"}, "text", func(values map[string]any) (string, error) {
+	var texts []string
+
+	for _, doc := range values["input_documents"].([]llm.Document) {
+		texts = append(texts, doc.PageContent)
+	}
+
+	return strings.Join(texts, "+"), nil
+})
+
+func TestChain(t *testing.T) {
+	var calls atomic.Int32
+
+	mapChain := mock.TextChain([]string{"context", "question"}, "text", func(values map[string]any) (string, error) {
+		calls.Add(1)
+
+		return fmt.Sprintf("%s:%s", values["question"], values["context"]), nil
+	})
+
+	c := New(mapChain, joinChain, func(c *Chain) {
+		c.MaxConcurrency = 2
+		c.ReturnIntermediateSteps = true
+	})
+
+	got, err := llm.ChainCall(context.Background(), c, map[string]any{
+		"question": "q",
+		"input_documents": []llm.Document{
+			{PageContent: "a"},
+			{PageContent: "b"},
+			{PageContent: "c"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]any{
+		"text":               "q:a+q:b+q:c",
+		"intermediate_steps": []string{"q:a", "q:b", "q:c"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got = %v, want %v", got, want)
+	}
+
+	if got, want := calls.Load(), int32(3); got != want {
+		t.Fatalf("calls = %d, want %d", got, want)
+	}
+}
+
+func TestChainCollapse(t *testing.T) {
+	var collapses atomic.Int32
+
+	mapChain := mock.TextChain([]string{"context"}, "text", func(values map[string]any) (string, error) {
+		return values["context"].(string), nil
+	})
+
+	collapseChain := mock.TextChain([]string{"input_documents"}, "text", func(values map[string]any) (string, error) {
+		collapses.Add(1)
+
+		return "x", nil
+	})
+
+	c := New(mapChain, joinChain, func(c *Chain) {
+		c.CollapseChain = collapseChain
+		c.TokenMax = 2
+		c.TokenCount = func(text string) int { return len(text) }
+	})
+
+	docs := []llm.Document{{PageContent: "a"}, {PageContent: "b"}, {PageContent: "c"}, {PageContent: "d"}}
+
+	got, err := llm.ChainRun(context.Background(), c, docs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "x+x"; got != want {
+		t.Fatalf("got = %q, want %q", got, want)
+	}
+
+	if got, want := collapses.Load(), int32(2); got != want {
+		t.Fatalf("collapses = %d, want %d", got, want)
+	}
+
+	c.TokenMax = 0
+
+	if _, err := llm.ChainRun(context.Background(), c, docs); !errors.Is(err, ErrTokenMaxExceeded) {
+		t.Fatalf("err = %v, want %v", err, ErrTokenMaxExceeded)
+	}
+}
+
+func TestChainMapError(t *testing.T) {
+	errMap := errors.New("map failed")
+
+	mapChain := mock.TextChain([]string{"context"}, "text", func(map[string]any) (string, error) {
+		return "", errMap
+	})
+
+	_, err := llm.ChainRun(context.Background(), New(mapChain, joinChain), []llm.Document{{PageContent: "a"}})
+	if !errors.Is(err, errMap) {
+		t.Fatalf("err = %v, want %v", err, errMap)
+	}
+}
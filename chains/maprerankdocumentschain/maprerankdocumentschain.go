@@ -0,0 +1,218 @@
+package maprerankdocumentschain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/parsers/regexparser"
+)
+
+var _ llm.Chain = Chain{}
+
+const (
+	defaultInputKey             = "input_documents"
+	defaultDocumentVariableName = "context"
+	defaultOutputKey            = "text"
+	defaultSourceDocumentKey    = "source_document"
+	defaultScoredAnswersKey     = "scored_answers"
+	defaultAnswerKey            = "answer"
+	defaultScoreKey             = "score"
+	defaultExpression           = `(?s)^\s*(?P<answer>.*?)\s*Score:\s*(?P<score>\d+(?:\.\d+)?)`
+)
+
+// DefaultTemplate is a Go template for the LLM chain, that asks for an
+// answer and a score in the format parsed by the default parser.
+const DefaultTemplate = `Use the following pieces of context to answer the question at the end. If you don't know the answer, just say that you don't know, don't try to make up an answer.
+
+In addition to giving an answer, also return a score of how fully it answered the user's question. This should be in the following format:
+
+Question: [question here]
+Helpful Answer: [answer here]
+Score: [score between 0 and 100]
+
+Begin!
+
+Context:
+---------
+{{.context}}
+---------
+Question: {{.question}}
+Helpful Answer:`
+
+// ScoredAnswer is the answer and score for a single document.
+type ScoredAnswer struct {
+	Answer   string
+	Score    float64
+	Document llm.Document
+}
+
+// Chain that combines documents by asking the LLM chain for an answer and a score
+// for each document, and returns the answer with the highest score together with
+// the document it is based on.
+//
+// The LLM chain is given all input values of the Chain, and the content of the
+// document in the key specified by DocumentVariableName, by default "context".
+// Its output is parsed by the Parser into the answer and the score.
+type Chain struct {
+	// LLMChain is called for each document.
+	LLMChain llm.Chain
+
+	// Parser parses the output of the LLM chain into a map[string]string with the
+	// answer and the score, by default a regexparser expecting "<answer> Score: <score>".
+	Parser llm.Parser[any]
+
+	// AnswerKey is the key of the answer in the parsed output, by default "answer".
+	AnswerKey string
+
+	// ScoreKey is the key of the score in the parsed output, by default "score".
+	ScoreKey string
+
+	// InputKey is the input key the documents are expected to be in,
+	// by default "input_documents".
+	InputKey string
+
+	// DocumentVariableName is the variable name used by the LLM chain
+	// for the content of the document, by default "context".
+	DocumentVariableName string
+
+	// OutputKey is the key of the top answer, by default "text".
+	OutputKey string
+
+	// ReturnScoredAnswers makes the chain return all scored answers, sorted
+	// by score, as a []ScoredAnswer in the "scored_answers" key.
+	ReturnScoredAnswers bool
+}
+
+// New creates a new map-rerank documents chain.
+func New(llmChain llm.Chain, options ...func(*Chain)) Chain {
+	c := Chain{
+		LLMChain:             llmChain,
+		Parser:               regexparser.New(defaultExpression),
+		AnswerKey:            defaultAnswerKey,
+		ScoreKey:             defaultScoreKey,
+		InputKey:             defaultInputKey,
+		DocumentVariableName: defaultDocumentVariableName,
+		OutputKey:            defaultOutputKey,
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	return c
+}
+
+// Call scores the answer for each document and returns the top answer and its source document.
+// Outputs that can not be parsed are skipped, unless none of the outputs could be parsed.
+func (c Chain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	docs, ok := values[c.InputKey].([]llm.Document)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", llm.ErrInvalidInputValues, llm.ErrInputValuesWrongType)
+	}
+
+	var (
+		answers  []ScoredAnswer
+		parseErr error
+	)
+
+	for _, doc := range docs {
+		text, err := c.callText(ctx, values, doc, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		answer, err := c.parse(text)
+		if err != nil {
+			parseErr = err
+			continue
+		}
+
+		answer.Document = doc
+		answers = append(answers, answer)
+	}
+
+	if len(answers) == 0 {
+		if parseErr != nil {
+			return nil, parseErr
+		}
+
+		return nil, fmt.Errorf("%w: no documents", llm.ErrInvalidInputValues)
+	}
+
+	sort.SliceStable(answers, func(i, j int) bool {
+		return answers[i].Score > answers[j].Score
+	})
+
+	result := map[string]any{
+		c.OutputKey:              answers[0].Answer,
+		defaultSourceDocumentKey: answers[0].Document,
+	}
+
+	if c.ReturnScoredAnswers {
+		result[defaultScoredAnswersKey] = answers
+	}
+
+	return result, nil
+}
+
+// Memory returns empty memory.
+func (c Chain) Memory() llm.Memory {
+	return llm.EmptyMemory{}
+}
+
+// InputKeys returns the expected input keys, by default "input_documents".
+func (c Chain) InputKeys() []string {
+	return []string{c.InputKey}
+}
+
+// OutputKeys returns the output key, by default "text", "source_document",
+// and "scored_answers" if ReturnScoredAnswers is set.
+func (c Chain) OutputKeys() []string {
+	keys := []string{c.OutputKey, defaultSourceDocumentKey}
+
+	if c.ReturnScoredAnswers {
+		keys = append(keys, defaultScoredAnswersKey)
+	}
+
+	return keys
+}
+
+// callText calls the chain with the page content of the document, and returns its single string output.
+func (c Chain) callText(ctx context.Context, values map[string]any, doc llm.Document, options ...llm.ChainOption) (string, error) {
+	inputs := make(map[string]any, len(values)+1)
+
+	for key, value := range values {
+		inputs[key] = value
+	}
+
+	inputs[c.DocumentVariableName] = doc.PageContent
+
+	return llm.ChainPredict(ctx, c.LLMChain, inputs, options...)
+}
+
+// parse parses the text into an answer and a score.
+func (c Chain) parse(text string) (ScoredAnswer, error) {
+	parsed, err := c.Parser.Parse(text)
+	if err != nil {
+		return ScoredAnswer{}, err
+	}
+
+	values, ok := parsed.(map[string]string)
+	if !ok {
+		return ScoredAnswer{}, llm.ParseError{Text: text, Reason: fmt.Sprintf("parsed output is %T, not map[string]string", parsed)}
+	}
+
+	score, err := strconv.ParseFloat(strings.TrimSpace(values[c.ScoreKey]), 64)
+	if err != nil {
+		return ScoredAnswer{}, llm.ParseError{Text: text, Reason: fmt.Sprintf("invalid score: %v", err)}
+	}
+
+	return ScoredAnswer{
+		Answer: strings.TrimSpace(values[c.AnswerKey]),
+		Score:  score,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package maprerankdocumentschain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+func TestChain(t *testing.T) {
+	outputs := map[string]string{
+		"a": "Paris is the capital.\nScore: 60",
+		"b": "The capital of France is Paris.\nScore: 95",
+		"c": "I don't know",
+	}
+
+	answer := mock.TextChain([]string{"context", "question"}, "text", func(values map[string]any) (string, error) {
+		return outputs[values["context"].(string)], nil
+	})
+
+	c := New(answer, func(c *Chain) {
+		c.ReturnScoredAnswers = true
+	})
+
+	got, err := llm.ChainCall(context.Background(), c, map[string]any{
+		"question": "What is the capital of France?",
+		"input_documents": []llm.Document{
+			{PageContent: "a"},
+			{PageContent: "b"},
+			{PageContent: "c"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := got["text"], "The capital of France is Paris."; got != want {
+		t.Fatalf("text = %q, want %q", got, want)
+	}
+
+	if got, want := got["source_document"].(llm.Document).PageContent, "b"; got != want {
+		t.Fatalf("source document = %q, want %q", got, want)
+	}
+
+	answers := got["scored_answers"].([]ScoredAnswer)
+
+	if got, want := len(answers), 2; got != want {
+		t.Fatalf("len(answers) = %d, want %d", got, want)
+	}
+
+	if got, want := answers[1].Score, 60.0; got != want {
+		t.Fatalf("answers[1].Score = %v, want %v", got, want)
+	}
+}
+
+func TestChainUnparsable(t *testing.T) {
+	c := New(mock.TextChain([]string{"context", "question"}, "text", func(map[string]any) (string, error) {
+		return "I don't know", nil
+	}))
+
+	_, err := llm.ChainCall(context.Background(), c, map[string]any{
+		"question":        "What is the capital of France?",
+		"input_documents": []llm.Document{{PageContent: "a"}},
+	})
+
+	var parseErr llm.ParseError
+
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("err = %v, want llm.ParseError", err)
+	}
+}
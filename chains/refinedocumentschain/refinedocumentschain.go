@@ -0,0 +1,150 @@
+package refinedocumentschain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Chain = Chain{}
+
+const (
+	defaultInputKey             = "input_documents"
+	defaultDocumentVariableName = "context"
+	defaultInitialResponseName  = "existing_answer"
+	defaultOutputKey            = "text"
+	defaultIntermediateStepsKey = "intermediate_steps"
+)
+
+// Chain that combines documents by calling the initial chain with the first
+// document, and then the refine chain once for each of the remaining documents
+// with the answer so far. It can be used wherever a stuffdocumentschain.Chain
+// is used, since it takes the documents in the same "input_documents" key.
+//
+// Both chains are given all input values of the Chain, and the content of the
+// document in the key specified by DocumentVariableName, by default "context".
+// The refine chain is also given the answer so far in the key specified by
+// InitialResponseName, by default "existing_answer". Both chains must return
+// a single string output.
+type Chain struct {
+	// InitialChain is called with the first document.
+	InitialChain llm.Chain
+
+	// RefineChain is called with each of the remaining documents.
+	RefineChain llm.Chain
+
+	// InputKey is the input key the documents are expected to be in,
+	// by default "input_documents".
+	InputKey string
+
+	// DocumentVariableName is the variable name used by the chains
+	// for the content of the document, by default "context".
+	DocumentVariableName string
+
+	// InitialResponseName is the variable name used by the refine
+	// chain for the answer so far, by default "existing_answer".
+	InitialResponseName string
+
+	// OutputKey is the key of the final answer, by default "text".
+	OutputKey string
+
+	// ReturnIntermediateSteps makes the chain return the answer after
+	// each document as a []string in the "intermediate_steps" key.
+	ReturnIntermediateSteps bool
+}
+
+// New creates a new refine documents chain.
+func New(initialChain, refineChain llm.Chain, options ...func(*Chain)) Chain {
+	c := Chain{
+		InitialChain:         initialChain,
+		RefineChain:          refineChain,
+		InputKey:             defaultInputKey,
+		DocumentVariableName: defaultDocumentVariableName,
+		InitialResponseName:  defaultInitialResponseName,
+		OutputKey:            defaultOutputKey,
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	return c
+}
+
+// Call answers using the first document, and refines the answer using the remaining documents.
+func (c Chain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	docs, ok := values[c.InputKey].([]llm.Document)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", llm.ErrInvalidInputValues, llm.ErrInputValuesWrongType)
+	}
+
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("%w: no documents", llm.ErrInvalidInputValues)
+	}
+
+	answer, err := c.call(ctx, c.InitialChain, values, docs[0], nil, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []string{answer}
+
+	for i := 1; i < len(docs); i++ {
+		answer, err = c.call(ctx, c.RefineChain, values, docs[i], &answer, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, answer)
+	}
+
+	result := map[string]any{
+		c.OutputKey: answer,
+	}
+
+	if c.ReturnIntermediateSteps {
+		result[defaultIntermediateStepsKey] = steps
+	}
+
+	return result, nil
+}
+
+// Memory returns empty memory.
+func (c Chain) Memory() llm.Memory {
+	return llm.EmptyMemory{}
+}
+
+// InputKeys returns the expected input keys, by default "input_documents".
+func (c Chain) InputKeys() []string {
+	return []string{c.InputKey}
+}
+
+// OutputKeys returns the output key, by default "text", and
+// "intermediate_steps" if ReturnIntermediateSteps is set.
+func (c Chain) OutputKeys() []string {
+	if c.ReturnIntermediateSteps {
+		return []string{c.OutputKey, defaultIntermediateStepsKey}
+	}
+
+	return []string{c.OutputKey}
+}
+
+// call calls the chain with the document and the existing answer, if any.
+func (c Chain) call(ctx context.Context, chain llm.Chain, values map[string]any,
+	doc llm.Document, answer *string, options ...llm.ChainOption,
+) (string, error) {
+	inputs := make(map[string]any, len(values)+2)
+
+	for key, value := range values {
+		inputs[key] = value
+	}
+
+	inputs[c.DocumentVariableName] = doc.PageContent
+
+	if answer != nil {
+		inputs[c.InitialResponseName] = *answer
+	}
+
+	return llm.ChainPredict(ctx, chain, inputs, options...)
+}
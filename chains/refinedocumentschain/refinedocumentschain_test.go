@@ -0,0 +1,102 @@
+package refinedocumentschain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+func TestChain(t *testing.T) {
+	initial := mock.TextChain([]string{"context"}, "text", func(values map[string]any) (string, error) {
+		return values["question"].(string) + ": " + values["context"].(string), nil
+	})
+
+	refine := mock.TextChain([]string{"context"}, "text", func(values map[string]any) (string, error) {
+		return values["existing_answer"].(string) + ", " + values["context"].(string), nil
+	})
+
+	c := New(initial, refine, func(c *Chain) {
+		c.ReturnIntermediateSteps = true
+	})
+
+	got, err := llm.ChainCall(context.Background(), c, map[string]any{
+		"question": "letters",
+		"input_documents": []llm.Document{
+			{PageContent: "a"},
+			{PageContent: "b"},
+			{PageContent: "c"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]any{
+		"text":               "letters: a, b, c",
+		"intermediate_steps": []string{"letters: a", "letters: a, b", "letters: a, b, c"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got = %v, want %v", got, want)
+	}
+}
+
+func TestChainNoDocuments(t *testing.T) {
+	called := false
+
+	initial := mock.TextChain([]string{"context"}, "text", func(map[string]any) (string, error) {
+		called = true
+
+		return "", nil
+	})
+
+	c := New(initial, initial)
+
+	_, err := llm.ChainCall(context.Background(), c, map[string]any{
+		"input_documents": []llm.Document{},
+	})
+	if !errors.Is(err, llm.ErrInvalidInputValues) {
+		t.Fatalf("err = %v, want %v", err, llm.ErrInvalidInputValues)
+	}
+
+	if called {
+		t.Fatalf("initial chain called without documents")
+	}
+}
+
+func TestChainError(t *testing.T) {
+	errRefine := errors.New("refine failed")
+
+	var calls int
+
+	initial := mock.TextChain([]string{"context"}, "text", func(map[string]any) (string, error) {
+		return "a", nil
+	})
+
+	refine := mock.TextChain([]string{"context"}, "text", func(map[string]any) (string, error) {
+		calls++
+
+		return "", errRefine
+	})
+
+	c := New(initial, refine)
+
+	_, err := llm.ChainCall(context.Background(), c, map[string]any{
+		"input_documents": []llm.Document{
+			{PageContent: "a"},
+			{PageContent: "b"},
+			{PageContent: "c"},
+		},
+	})
+	if !errors.Is(err, errRefine) {
+		t.Fatalf("err = %v, want %v", err, errRefine)
+	}
+
+	if got, want := calls, 1; got != want {
+		t.Fatalf("calls = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,148 @@
+package routerchain
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Chain = EmbeddingChain{}
+
+// EmbeddingChain forwards the input values to the destination with the description
+// most similar to the input, using the cosine similarity of their embeddings.
+// No provider is called to choose the destination.
+type EmbeddingChain struct {
+	// Embedder is used to embed the input.
+	Embedder llm.Embedder
+
+	// Destinations are the destinations to choose between.
+	Destinations []Destination
+
+	// Default is the chain used when no destination is similar enough, may be nil.
+	Default llm.Chain
+
+	// Threshold is the minimum similarity for a destination to be chosen.
+	Threshold float32
+
+	// InputKey is the key of the input to route, by default "input".
+	InputKey string
+
+	// Outputs are the output keys of the destination chains,
+	// by default the output keys of the first destination.
+	Outputs []string
+
+	// ReturnDestination makes the chain return the name of the
+	// chosen destination in the "destination" key.
+	ReturnDestination bool
+
+	embeddings [][]float32
+}
+
+// NewEmbedding creates a new embedding router chain. The descriptions of the destinations
+// are embedded when the chain is created. It returns an error if the destinations and the
+// default chain return different output keys.
+func NewEmbedding(ctx context.Context, embedder llm.Embedder, destinations []Destination,
+	defaultChain llm.Chain, options ...func(*EmbeddingChain),
+) (EmbeddingChain, error) {
+	c := EmbeddingChain{
+		Embedder:     embedder,
+		Destinations: destinations,
+		Default:      defaultChain,
+		InputKey:     defaultInputKey,
+		Outputs:      defaultOutputs(destinations, defaultChain),
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	if err := checkOutputs(c.Destinations, c.Default, c.Outputs); err != nil {
+		return c, err
+	}
+
+	descriptions := make([]string, len(c.Destinations))
+
+	for i, d := range c.Destinations {
+		descriptions[i] = d.Description
+	}
+
+	embeddings, err := c.Embedder.EmbedDocuments(ctx, descriptions)
+	if err != nil {
+		return c, fmt.Errorf("%w: %w", llm.ErrChainInitialization, err)
+	}
+
+	if len(embeddings) != len(c.Destinations) {
+		return c, fmt.Errorf("%w: got %d embeddings for %d destinations",
+			llm.ErrChainInitialization, len(embeddings), len(c.Destinations))
+	}
+
+	c.embeddings = embeddings
+
+	return c, nil
+}
+
+// Call embeds the input and forwards the input values to the most similar destination.
+func (c EmbeddingChain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	input, ok := values[c.InputKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", llm.ErrInvalidInputValues, llm.ErrInputValuesWrongType)
+	}
+
+	embedding, err := c.Embedder.EmbedQuery(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		name string
+		best = c.Threshold
+	)
+
+	for i, e := range c.embeddings {
+		if similarity := CosineSimilarity(embedding, e); similarity >= best {
+			name, best = c.Destinations[i].Name, similarity
+		}
+	}
+
+	return route(ctx, c.Destinations, c.Default, name, values, c.ReturnDestination, options...)
+}
+
+// Memory returns empty memory.
+func (c EmbeddingChain) Memory() llm.Memory {
+	return llm.EmptyMemory{}
+}
+
+// InputKeys returns the input key, by default "input".
+func (c EmbeddingChain) InputKeys() []string {
+	return []string{c.InputKey}
+}
+
+// OutputKeys returns the output keys of the destination chains, and
+// "destination" if ReturnDestination is set.
+func (c EmbeddingChain) OutputKeys() []string {
+	return outputKeys(c.Outputs, c.ReturnDestination)
+}
+
+// CosineSimilarity returns the cosine similarity of the vectors,
+// or 0 if they differ in length or either has zero magnitude.
+func CosineSimilarity(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
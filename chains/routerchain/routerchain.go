@@ -0,0 +1,289 @@
+package routerchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Chain = Chain{}
+
+const (
+	defaultInputKey       = "input"
+	defaultDestinationKey = "destination"
+
+	// DefaultDestination is the name the router responds with when no destination fits the input.
+	DefaultDestination = "DEFAULT"
+)
+
+const defaultTemplate = `Given a raw text input to a language model, select the destination best suited for the input.
+You will be given the names of the available destinations and a description of what each destination is best suited for.
+
+Destinations:
+{{.destinations}}
+
+Respond with only the name of the destination, or {{.default}} if none of the destinations are well suited for the input.
+
+Input:
+{{.input}}
+
+Destination:`
+
+// ErrNoDestination is returned when no destination was chosen and there is no default chain.
+var ErrNoDestination = errors.New("no destination chosen and no default chain")
+
+// Destination is a named chain that inputs can be routed to.
+type Destination struct {
+	// Name is the name of the destination, e.g. "billing".
+	Name string
+
+	// Description describes what kind of inputs the destination is suited for.
+	Description string
+
+	// Chain is the chain the input values are forwarded to.
+	Chain llm.Chain
+}
+
+// Chain asks a provider to choose the destination best suited for the input,
+// and then forwards the input values to the chain of that destination. The
+// Default chain is used if the provider does not respond with the name of
+// a destination.
+type Chain struct {
+	// Provider is used to choose the destination.
+	Provider llm.Provider
+
+	// Destinations are the destinations to choose between.
+	Destinations []Destination
+
+	// Default is the chain used when no destination is chosen, may be nil.
+	Default llm.Chain
+
+	// Prompt is the prompt used to choose the destination, it is given the
+	// "input", "destinations" and "default" values.
+	Prompt llm.PromptFormatter
+
+	// InputKey is the key of the input to route, by default "input".
+	InputKey string
+
+	// Outputs are the output keys of the destination chains,
+	// by default the output keys of the first destination.
+	Outputs []string
+
+	// ReturnDestination makes the chain return the name of the
+	// chosen destination in the "destination" key.
+	ReturnDestination bool
+
+	patterns map[string]*regexp.Regexp
+}
+
+// New creates a new router chain that uses the provider to choose between the destinations.
+// It returns an error if the destinations and the default chain return different output keys.
+func New(provider llm.Provider, destinations []Destination, defaultChain llm.Chain, options ...func(*Chain)) (Chain, error) {
+	c := Chain{
+		Provider:     provider,
+		Destinations: destinations,
+		Default:      defaultChain,
+		Prompt:       llm.GoTemplate(defaultTemplate, []string{"input", "destinations", "default"}),
+		InputKey:     defaultInputKey,
+		Outputs:      defaultOutputs(destinations, defaultChain),
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	if err := checkOutputs(c.Destinations, c.Default, c.Outputs); err != nil {
+		return c, err
+	}
+
+	c.patterns = compilePatterns(c.Destinations)
+
+	return c, nil
+}
+
+// Call asks the provider to choose a destination and forwards the input values to it.
+func (c Chain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	input, ok := values[c.InputKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", llm.ErrInvalidInputValues, llm.ErrInputValuesWrongType)
+	}
+
+	prompt, err := c.Prompt.FormatPrompt(map[string]any{
+		"input":        input,
+		"destinations": formatDestinations(c.Destinations),
+		"default":      DefaultDestination,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The destination is chosen with a temperature of 0, unless set by the options.
+	contentOptions := append([]llm.ContentOption{llm.WithTemperature(0)}, llm.ChainToContentOptions(options...)...)
+
+	output, err := llm.Call(ctx, c.Provider, prompt.String(), contentOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return route(ctx, c.Destinations, c.Default, parseDestination(output, c.Destinations, c.patterns), values, c.ReturnDestination, options...)
+}
+
+// Memory returns empty memory.
+func (c Chain) Memory() llm.Memory {
+	return llm.EmptyMemory{}
+}
+
+// InputKeys returns the input key, by default "input".
+func (c Chain) InputKeys() []string {
+	return []string{c.InputKey}
+}
+
+// OutputKeys returns the output keys of the destination chains, and
+// "destination" if ReturnDestination is set.
+func (c Chain) OutputKeys() []string {
+	return outputKeys(c.Outputs, c.ReturnDestination)
+}
+
+// ParseDestination returns the name of the destination in the output of the provider,
+// or an empty string if the output does not contain the name of any destination.
+// Names are matched case insensitively, first against the whole output and then
+// against the words in the output.
+func ParseDestination(output string, destinations []Destination) string {
+	return parseDestination(output, destinations, compilePatterns(destinations))
+}
+
+// parseDestination is ParseDestination using the patterns compiled for the destinations,
+// destinations without a pattern, e.g. added after New, are compiled on demand.
+func parseDestination(output string, destinations []Destination, patterns map[string]*regexp.Regexp) string {
+	output = strings.Trim(strings.TrimSpace(output), "`\"'.")
+
+	for _, d := range destinations {
+		if strings.EqualFold(output, d.Name) {
+			return d.Name
+		}
+	}
+
+	var (
+		first = -1
+		name  string
+	)
+
+	for _, d := range destinations {
+		re, ok := patterns[d.Name]
+		if !ok {
+			re = compilePattern(d.Name)
+		}
+
+		if loc := re.FindStringIndex(output); loc != nil && (first == -1 || loc[0] < first) {
+			first, name = loc[0], d.Name
+		}
+	}
+
+	return name
+}
+
+// route forwards the values to the chain of the named destination, or the default chain.
+func route(ctx context.Context, destinations []Destination, defaultChain llm.Chain, name string,
+	values map[string]any, returnDestination bool, options ...llm.ChainOption,
+) (map[string]any, error) {
+	chain, found := defaultChain, false
+
+	for _, d := range destinations {
+		if d.Name == name {
+			chain, found = d.Chain, true
+			break
+		}
+	}
+
+	if !found {
+		name = DefaultDestination
+	}
+
+	if chain == nil {
+		return nil, ErrNoDestination
+	}
+
+	result, err := llm.ChainCall(ctx, chain, values, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if returnDestination {
+		result[defaultDestinationKey] = name
+	}
+
+	return result, nil
+}
+
+// compilePatterns compiles the patterns matching the name of each destination as a word.
+func compilePatterns(destinations []Destination) map[string]*regexp.Regexp {
+	patterns := make(map[string]*regexp.Regexp, len(destinations))
+
+	for _, d := range destinations {
+		patterns[d.Name] = compilePattern(d.Name)
+	}
+
+	return patterns
+}
+
+func compilePattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(name) + `\b`)
+}
+
+func formatDestinations(destinations []Destination) string {
+	lines := make([]string, len(destinations))
+
+	for i, d := range destinations {
+		lines[i] = d.Name + ": " + d.Description
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func defaultOutputs(destinations []Destination, defaultChain llm.Chain) []string {
+	if len(destinations) > 0 {
+		return destinations[0].Chain.OutputKeys()
+	}
+
+	if defaultChain != nil {
+		return defaultChain.OutputKeys()
+	}
+
+	return nil
+}
+
+// checkOutputs returns an error if any of the destinations, or the default chain, do not return the outputs.
+func checkOutputs(destinations []Destination, defaultChain llm.Chain, outputs []string) error {
+	want := slices.Sorted(slices.Values(outputs))
+
+	for _, d := range destinations {
+		if got := slices.Sorted(slices.Values(d.Chain.OutputKeys())); !slices.Equal(got, want) {
+			return fmt.Errorf("%w: destination %q returns output keys %q, want %q",
+				llm.ErrChainInitialization, d.Name, got, want)
+		}
+	}
+
+	if defaultChain != nil {
+		if got := slices.Sorted(slices.Values(defaultChain.OutputKeys())); !slices.Equal(got, want) {
+			return fmt.Errorf("%w: default chain returns output keys %q, want %q",
+				llm.ErrChainInitialization, got, want)
+		}
+	}
+
+	return nil
+}
+
+func outputKeys(outputs []string, returnDestination bool) []string {
+	keys := append([]string{}, outputs...)
+
+	if returnDestination {
+		keys = append(keys, defaultDestinationKey)
+	}
+
+	return keys
+}
@@ -0,0 +1,204 @@
+package routerchain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+// namedChain returns a chain that returns its name.
+func namedChain(name string) mock.Chain {
+	return mock.TextChain([]string{"input"}, "text", func(map[string]any) (string, error) {
+		return name, nil
+	})
+}
+
+var destinations = []Destination{
+	{Name: "billing", Description: "Questions about invoices and payments", Chain: namedChain("billing")},
+	{Name: "technical", Description: "Questions about errors and bugs", Chain: namedChain("technical")},
+}
+
+func TestChain(t *testing.T) {
+	for _, tt := range []struct {
+		response string
+		want     string
+	}{
+		{"billing", "billing"},
+		{" Technical.\n", "technical"},
+		{"The best destination is `technical`", "technical"},
+		{"DEFAULT", "general"},
+		{"weather", "general"},
+	} {
+		provider := mock.Provider{
+			GenerateContentFunc: func(_ context.Context, ms []llm.Message, _ ...llm.ContentOption) (*llm.ContentResponse, error) {
+				if prompt := ms[0].Parts[0].(llm.TextContent).Text; !strings.Contains(prompt, "billing: Questions about invoices") {
+					t.Fatalf("prompt does not contain destinations: %q", prompt)
+				}
+
+				return &llm.ContentResponse{Choices: []*llm.ContentChoice{{Content: tt.response}}}, nil
+			},
+		}
+
+		c, err := New(provider, destinations, namedChain("general"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got, want := len(c.patterns), len(destinations); got != want {
+			t.Fatalf("len(c.patterns) = %d, want %d", got, want)
+		}
+
+		got, err := llm.ChainRun(context.Background(), c, "My invoice is wrong")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("response %q routed to %q, want %q", tt.response, got, tt.want)
+		}
+	}
+}
+
+func TestChainOptions(t *testing.T) {
+	var opts llm.ContentOptions
+
+	provider := mock.Provider{
+		GenerateContentFunc: func(_ context.Context, _ []llm.Message, options ...llm.ContentOption) (*llm.ContentResponse, error) {
+			for _, opt := range options {
+				opt(&opts)
+			}
+
+			return &llm.ContentResponse{Choices: []*llm.ContentChoice{{Content: "billing"}}}, nil
+		},
+	}
+
+	c, err := New(provider, destinations, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = llm.ChainRun(context.Background(), c, "My invoice is wrong",
+		llm.ChainWithModel("router"),
+		llm.ChainWithMaxTokens(5),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := opts.Model, "router"; got != want {
+		t.Fatalf("opts.Model = %q, want %q", got, want)
+	}
+
+	if got, want := opts.MaxTokens, 5; got != want {
+		t.Fatalf("opts.MaxTokens = %d, want %d", got, want)
+	}
+
+	if got, want := opts.Temperature, 0.0; got != want {
+		t.Fatalf("opts.Temperature = %v, want %v", got, want)
+	}
+}
+
+func TestChainWithoutDefault(t *testing.T) {
+	provider := mock.Provider{
+		GenerateContentFunc: func(context.Context, []llm.Message, ...llm.ContentOption) (*llm.ContentResponse, error) {
+			return &llm.ContentResponse{Choices: []*llm.ContentChoice{{Content: "DEFAULT"}}}, nil
+		},
+	}
+
+	c, err := New(provider, destinations, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = llm.ChainRun(context.Background(), c, "Hello")
+	if !errors.Is(err, ErrNoDestination) {
+		t.Fatalf("err = %v, want %v", err, ErrNoDestination)
+	}
+}
+
+func TestNewOutputMismatch(t *testing.T) {
+	answer := mock.TextChain([]string{"input"}, "answer", func(map[string]any) (string, error) {
+		return "", nil
+	})
+
+	if _, err := New(mock.Provider{}, destinations, answer); !errors.Is(err, llm.ErrChainInitialization) {
+		t.Fatalf("err = %v, want %v", err, llm.ErrChainInitialization)
+	}
+
+	mismatched := append([]Destination{}, destinations...)
+	mismatched[1].Chain = answer
+
+	if _, err := NewEmbedding(context.Background(), nil, mismatched, nil); !errors.Is(err, llm.ErrChainInitialization) {
+		t.Fatalf("err = %v, want %v", err, llm.ErrChainInitialization)
+	}
+}
+
+func TestEmbeddingChain(t *testing.T) {
+	vectors := map[string][]float32{
+		"Questions about invoices and payments": {1, 0, 0},
+		"Questions about errors and bugs":       {0, 1, 0},
+		"Why was I charged twice?":              {0.9, 0.1, 0},
+		"What is the meaning of life?":          {0, 0, 1},
+		"Why does the app crash?":               {0.1, 0.9, 0},
+	}
+
+	embedder, err := llm.NewEmbedder(mock.Provider{
+		CreateEmbeddingFunc: func(_ context.Context, texts []string) ([][]float32, error) {
+			embeddings := make([][]float32, len(texts))
+
+			for i, text := range texts {
+				embeddings[i] = vectors[text]
+			}
+
+			return embeddings, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c, err := NewEmbedding(context.Background(), embedder, destinations, namedChain("general"), func(c *EmbeddingChain) {
+		c.Threshold = 0.5
+		c.ReturnDestination = true
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for input, want := range map[string]string{
+		"Why was I charged twice?":     "billing",
+		"What is the meaning of life?": DefaultDestination,
+	} {
+		got, err := llm.ChainCall(context.Background(), c, map[string]any{"input": input})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got["destination"] != want {
+			t.Fatalf("destination = %q, want %q", got["destination"], want)
+		}
+	}
+
+	// Destinations changed by an option are the ones embedded and routed to.
+	c, err = NewEmbedding(context.Background(), embedder, destinations, namedChain("general"), func(c *EmbeddingChain) {
+		c.Destinations = destinations[1:]
+		c.Threshold = 0.5
+		c.ReturnDestination = true
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := llm.ChainCall(context.Background(), c, map[string]any{"input": "Why does the app crash?"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "technical"; got["destination"] != want {
+		t.Fatalf("destination = %q, want %q", got["destination"], want)
+	}
+}
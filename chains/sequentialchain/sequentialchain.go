@@ -0,0 +1,162 @@
+package sequentialchain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Chain = Chain{}
+
+// Chain calls a list of chains in order, where the input values of each chain
+// are the initial input values of the Chain and the output values of the
+// chains called before it.
+type Chain struct {
+	// Chains are the chains to call, in order.
+	Chains []llm.Chain
+
+	// Inputs are the input keys of the Chain.
+	Inputs []string
+
+	// Outputs are the output keys returned by the Chain,
+	// by default the output keys of the last chain.
+	Outputs []string
+
+	// ReturnAll makes the Chain return the output values of all chains,
+	// instead of only the Outputs.
+	ReturnAll bool
+
+	// Hooks are called with the input and output values of each chain.
+	Hooks llm.ChainHooks
+}
+
+// New creates a new sequential chain. It returns an error if the input keys of a chain are
+// not in the given input keys, the output keys of an earlier chain, or the memory of the chain.
+func New(chains []llm.Chain, inputKeys []string, options ...func(*Chain)) (Chain, error) {
+	c := Chain{
+		Chains: chains,
+		Inputs: inputKeys,
+	}
+
+	if len(chains) > 0 {
+		c.Outputs = chains[len(chains)-1].OutputKeys()
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	return c, c.validate()
+}
+
+func (c Chain) validate() error {
+	if len(c.Chains) == 0 {
+		return fmt.Errorf("%w: no chains", llm.ErrChainInitialization)
+	}
+
+	known := slices.Clone(c.Inputs)
+
+	for i, chain := range c.Chains {
+		memoryKeys := chain.Memory().Variables(context.Background())
+
+		for _, key := range chain.InputKeys() {
+			if !slices.Contains(known, key) && !slices.Contains(memoryKeys, key) {
+				return fmt.Errorf("%w: missing input key %q for chain %d", llm.ErrChainInitialization, key, i)
+			}
+		}
+
+		for _, key := range chain.OutputKeys() {
+			if slices.Contains(known, key) {
+				return fmt.Errorf("%w: output key %q of chain %d is already used", llm.ErrChainInitialization, key, i)
+			}
+
+			known = append(known, key)
+		}
+	}
+
+	for _, key := range c.Outputs {
+		if !slices.Contains(known, key) {
+			return fmt.Errorf("%w: output key %q is not returned by any chain", llm.ErrChainInitialization, key)
+		}
+	}
+
+	return nil
+}
+
+// Call calls the chains in order.
+func (c Chain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	known := make(map[string]any, len(values))
+
+	for key, value := range values {
+		known[key] = value
+	}
+
+	for _, chain := range c.Chains {
+		outputs, err := callStep(ctx, c.Hooks, chain, known, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range outputs {
+			known[key] = value
+		}
+	}
+
+	result := make(map[string]any)
+
+	for _, key := range c.OutputKeys() {
+		result[key] = known[key]
+	}
+
+	return result, nil
+}
+
+// Memory returns empty memory.
+func (c Chain) Memory() llm.Memory {
+	return llm.EmptyMemory{}
+}
+
+// InputKeys returns the input keys of the Chain.
+func (c Chain) InputKeys() []string {
+	return c.Inputs
+}
+
+// OutputKeys returns the output keys of the Chain, or the
+// output keys of all chains if ReturnAll is set.
+func (c Chain) OutputKeys() []string {
+	if !c.ReturnAll {
+		return c.Outputs
+	}
+
+	var keys []string
+
+	for _, chain := range c.Chains {
+		keys = append(keys, chain.OutputKeys()...)
+	}
+
+	return keys
+}
+
+// callStep calls the chain and reports the step to the hooks, if any.
+func callStep(ctx context.Context, hooks llm.ChainHooks, chain llm.Chain, inputs map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	if hooks != nil {
+		hooks.ChainStart(ctx, inputs)
+	}
+
+	outputs, err := llm.ChainCall(ctx, chain, inputs, options...)
+	if err != nil {
+		if hooks != nil {
+			hooks.ChainError(ctx, err)
+		}
+
+		return nil, err
+	}
+
+	if hooks != nil {
+		hooks.ChainEnd(ctx, outputs)
+	}
+
+	return outputs, nil
+}
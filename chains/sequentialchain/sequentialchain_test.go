@@ -0,0 +1,93 @@
+package sequentialchain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+// upperChain returns a chain that upper cases the input key into the output key.
+func upperChain(inputKey, outputKey string) mock.Chain {
+	return mock.TextChain([]string{inputKey}, outputKey, func(values map[string]any) (string, error) {
+		return strings.ToUpper(values[inputKey].(string)) + "!", nil
+	})
+}
+
+func TestNew(t *testing.T) {
+	var steps []map[string]any
+
+	hooks := mock.Hooks{
+		ChainStartFunc: func(context.Context, map[string]any) {},
+		ChainEndFunc: func(_ context.Context, out map[string]any) {
+			steps = append(steps, out)
+		},
+	}
+
+	c, err := New([]llm.Chain{
+		upperChain("topic", "title"),
+		upperChain("title", "summary"),
+	}, []string{"topic"}, func(c *Chain) {
+		c.ReturnAll = true
+		c.Hooks = hooks
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := llm.ChainCall(context.Background(), c, map[string]any{"topic": "go"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]any{"title": "GO!", "summary": "GO!!"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got = %v, want %v", got, want)
+	}
+
+	if got, want := len(steps), 2; got != want {
+		t.Fatalf("len(steps) = %d, want %d", got, want)
+	}
+}
+
+func TestNewMissingInputKey(t *testing.T) {
+	_, err := New([]llm.Chain{
+		upperChain("topic", "title"),
+		upperChain("subtitle", "summary"),
+	}, []string{"topic"})
+
+	if !errors.Is(err, llm.ErrChainInitialization) {
+		t.Fatalf("err = %v, want %v", err, llm.ErrChainInitialization)
+	}
+}
+
+func TestNewSimple(t *testing.T) {
+	c, err := NewSimple([]llm.Chain{
+		upperChain("text", "text"),
+		upperChain("question", "answer"),
+	}, func(c *SimpleChain) {
+		c.ReturnIntermediateOutputs = true
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := llm.ChainCall(context.Background(), c, map[string]any{"input": "hi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]any{
+		"output":               "HI!!",
+		"intermediate_outputs": []string{"HI!", "HI!!"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got = %v, want %v", got, want)
+	}
+}
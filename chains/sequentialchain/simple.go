@@ -0,0 +1,148 @@
+package sequentialchain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Chain = SimpleChain{}
+
+const (
+	defaultInputKey               = "input"
+	defaultOutputKey              = "output"
+	defaultIntermediateOutputsKey = "intermediate_outputs"
+)
+
+// SimpleChain calls a list of chains in order, where the string output
+// of each chain is the input of the next chain. Each chain must have a
+// single input key, not counting keys from its memory, and a single
+// output key.
+type SimpleChain struct {
+	// Chains are the chains to call, in order.
+	Chains []llm.Chain
+
+	// InputKey is the key of the input to the first chain, by default "input".
+	InputKey string
+
+	// OutputKey is the key of the output of the last chain, by default "output".
+	OutputKey string
+
+	// ReturnIntermediateOutputs makes the chain return the outputs
+	// of all chains as a []string in the "intermediate_outputs" key.
+	ReturnIntermediateOutputs bool
+
+	// Hooks are called with the input and output values of each chain.
+	Hooks llm.ChainHooks
+}
+
+// NewSimple creates a new simple sequential chain. It returns an error if
+// any of the chains has more than one input key or more than one output key.
+func NewSimple(chains []llm.Chain, options ...func(*SimpleChain)) (SimpleChain, error) {
+	c := SimpleChain{
+		Chains:    chains,
+		InputKey:  defaultInputKey,
+		OutputKey: defaultOutputKey,
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	if len(c.Chains) == 0 {
+		return c, fmt.Errorf("%w: no chains", llm.ErrChainInitialization)
+	}
+
+	for i, chain := range c.Chains {
+		if _, err := singleInputKey(context.Background(), chain); err != nil {
+			return c, fmt.Errorf("%w: chain %d: %w", llm.ErrChainInitialization, i, err)
+		}
+
+		if len(chain.OutputKeys()) != 1 {
+			return c, fmt.Errorf("%w: chain %d: %w", llm.ErrChainInitialization, i, llm.ErrMultipleOutputsInRun)
+		}
+	}
+
+	return c, nil
+}
+
+// Call calls the chains in order, with the output of each chain as the input to the next.
+func (c SimpleChain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	input, ok := values[c.InputKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", llm.ErrInvalidInputValues, llm.ErrInputValuesWrongType)
+	}
+
+	intermediate := make([]string, 0, len(c.Chains))
+
+	for _, chain := range c.Chains {
+		inputKey, err := singleInputKey(ctx, chain)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs, err := callStep(ctx, c.Hooks, chain, map[string]any{inputKey: input}, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		output, ok := outputs[chain.OutputKeys()[0]].(string)
+		if !ok {
+			return nil, llm.ErrWrongOutputTypeInRun
+		}
+
+		intermediate = append(intermediate, output)
+		input = output
+	}
+
+	result := map[string]any{
+		c.OutputKey: input,
+	}
+
+	if c.ReturnIntermediateOutputs {
+		result[defaultIntermediateOutputsKey] = intermediate
+	}
+
+	return result, nil
+}
+
+// Memory returns empty memory.
+func (c SimpleChain) Memory() llm.Memory {
+	return llm.EmptyMemory{}
+}
+
+// InputKeys returns the input key, by default "input".
+func (c SimpleChain) InputKeys() []string {
+	return []string{c.InputKey}
+}
+
+// OutputKeys returns the output key, by default "output", and
+// "intermediate_outputs" if ReturnIntermediateOutputs is set.
+func (c SimpleChain) OutputKeys() []string {
+	if c.ReturnIntermediateOutputs {
+		return []string{c.OutputKey, defaultIntermediateOutputsKey}
+	}
+
+	return []string{c.OutputKey}
+}
+
+// singleInputKey returns the only input key of the chain that is not provided by its memory.
+func singleInputKey(ctx context.Context, chain llm.Chain) (string, error) {
+	memoryKeys := chain.Memory().Variables(ctx)
+
+	var keys []string
+
+	for _, key := range chain.InputKeys() {
+		if !slices.Contains(memoryKeys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) != 1 {
+		return "", llm.ErrMultipleInputsInRun
+	}
+
+	return keys[0], nil
+}
@@ -0,0 +1,196 @@
+package sqldatabasechain
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSampleRows = 3
+	defaultTimeout    = 30 * time.Second
+)
+
+// Database describes the tables of a database, and runs read-only queries against it.
+type Database struct {
+	// DB is the database.
+	DB *sql.DB
+
+	// Dialect is used to introspect the schema of the database.
+	Dialect Dialect
+
+	// Tables are the tables described to the provider, by default all tables.
+	Tables []string
+
+	// SampleRows is the number of rows of each table included in the table info, by default 3.
+	SampleRows int
+
+	// Timeout is the maximum duration of a query, by default 30 seconds.
+	Timeout time.Duration
+}
+
+// NewDatabase creates a new database using the dialect.
+func NewDatabase(db *sql.DB, dialect Dialect, options ...func(*Database)) *Database {
+	d := &Database{
+		DB:         db,
+		Dialect:    dialect,
+		SampleRows: defaultSampleRows,
+		Timeout:    defaultTimeout,
+	}
+
+	for _, opt := range options {
+		opt(d)
+	}
+
+	return d
+}
+
+// TableNames returns the names of the tables described to the provider.
+func (d *Database) TableNames(ctx context.Context) ([]string, error) {
+	names, err := d.Dialect.TableNames(ctx, d.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(d.Tables) == 0 {
+		return names, nil
+	}
+
+	for _, table := range d.Tables {
+		if !slices.Contains(names, table) {
+			return nil, fmt.Errorf("table %q not found in database", table)
+		}
+	}
+
+	return d.Tables, nil
+}
+
+// TableInfo returns the CREATE TABLE statement of each table, followed by sample rows.
+func (d *Database) TableInfo(ctx context.Context) (string, error) {
+	tables, err := d.TableNames(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	infos := make([]string, 0, len(tables))
+
+	for _, table := range tables {
+		info, err := d.tableInfo(ctx, table)
+		if err != nil {
+			return "", err
+		}
+
+		infos = append(infos, info)
+	}
+
+	return strings.Join(infos, "\n\n"), nil
+}
+
+func (d *Database) tableInfo(ctx context.Context, table string) (string, error) {
+	stmt, err := d.Dialect.CreateTable(ctx, d.DB, table)
+	if err != nil {
+		return "", err
+	}
+
+	if d.SampleRows <= 0 {
+		return stmt, nil
+	}
+
+	columns, rows, err := d.Query(ctx, "SELECT * FROM "+d.Dialect.QuoteIdentifier(table), d.SampleRows)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n\n/*\n%d rows from %s table:\n%s*/", stmt, len(rows), table, FormatRows(columns, rows)), nil
+}
+
+// Query runs the query in a read-only transaction that is rolled back afterwards, and returns the
+// column names and at most limit rows with each value formatted as a string. The query is limited
+// using the dialect, a limit of 0 or less means no limit. The query is not validated, use
+// ValidateQuery for queries from a provider.
+func (d *Database) Query(ctx context.Context, query string, limit int) ([]string, [][]string, error) {
+	if d.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, d.Timeout)
+		defer cancel()
+	}
+
+	if limit > 0 {
+		query = d.Dialect.Limit(query, limit)
+	}
+
+	tx, err := d.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		result [][]string
+		values = make([]any, len(columns))
+		dest   = make([]any, len(columns))
+	)
+
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, err
+		}
+
+		row := make([]string, len(values))
+
+		for i, v := range values {
+			row[i] = formatValue(v)
+		}
+
+		result = append(result, row)
+	}
+
+	return columns, result, rows.Err()
+}
+
+// FormatRows formats the columns and rows as tab separated lines.
+func FormatRows(columns []string, rows [][]string) string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.Join(columns, "\t") + "\n")
+
+	for _, row := range rows {
+		sb.WriteString(strings.Join(row, "\t") + "\n")
+	}
+
+	return sb.String()
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,203 @@
+package sqldatabasechain
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Dialect introspects the schema of a database using a specific SQL dialect.
+type Dialect interface {
+	// Name is the name of the dialect given to the provider, e.g. "SQLite".
+	Name() string
+
+	// TableNames returns the names of the tables in the database.
+	TableNames(ctx context.Context, db *sql.DB) ([]string, error)
+
+	// CreateTable returns the CREATE TABLE statement for the table.
+	CreateTable(ctx context.Context, db *sql.DB, table string) (string, error)
+
+	// QuoteIdentifier quotes the name of a table or column.
+	QuoteIdentifier(name string) string
+
+	// Limit returns the query limited to at most n rows.
+	Limit(query string, n int) string
+}
+
+var (
+	_ Dialect = SQLite{}
+	_ Dialect = PostgreSQL{}
+	_ Dialect = MySQL{}
+)
+
+// SQLite is the dialect for SQLite databases.
+type SQLite struct{}
+
+// Name returns "SQLite".
+func (SQLite) Name() string {
+	return "SQLite"
+}
+
+// TableNames returns the names of the tables in the database, excluding internal tables.
+func (SQLite) TableNames(ctx context.Context, db *sql.DB) ([]string, error) {
+	return queryStrings(ctx, db, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`)
+}
+
+// CreateTable returns the CREATE TABLE statement stored by SQLite.
+func (SQLite) CreateTable(ctx context.Context, db *sql.DB, table string) (string, error) {
+	var stmt string
+
+	if err := db.QueryRowContext(ctx, `SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&stmt); err != nil {
+		return "", err
+	}
+
+	return stmt, nil
+}
+
+// QuoteIdentifier quotes the name using double quotes.
+func (SQLite) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, `"`)
+}
+
+// Limit wraps the query in a subquery limited to n rows.
+func (SQLite) Limit(query string, n int) string {
+	return limitSubquery(query, n)
+}
+
+// PostgreSQL is the dialect for PostgreSQL databases.
+type PostgreSQL struct {
+	// Schema is the schema of the tables, by default "public".
+	Schema string
+}
+
+// Name returns "PostgreSQL".
+func (PostgreSQL) Name() string {
+	return "PostgreSQL"
+}
+
+// TableNames returns the names of the tables in the schema.
+func (d PostgreSQL) TableNames(ctx context.Context, db *sql.DB) ([]string, error) {
+	return queryStrings(ctx, db, `SELECT table_name FROM information_schema.tables
+WHERE table_schema = $1 AND table_type = 'BASE TABLE' ORDER BY table_name`, d.schema())
+}
+
+// CreateTable returns a CREATE TABLE statement built from the columns of the table.
+func (d PostgreSQL) CreateTable(ctx context.Context, db *sql.DB, table string) (string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT column_name, data_type, is_nullable FROM information_schema.columns
+WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position`, d.schema(), table)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var columns []string
+
+	for rows.Next() {
+		var name, dataType, nullable string
+
+		if err := rows.Scan(&name, &dataType, &nullable); err != nil {
+			return "", err
+		}
+
+		column := "\t" + d.QuoteIdentifier(name) + " " + dataType
+
+		if nullable == "NO" {
+			column += " NOT NULL"
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n)", d.QuoteIdentifier(table), strings.Join(columns, ",\n")), nil
+}
+
+// QuoteIdentifier quotes the name using double quotes.
+func (PostgreSQL) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, `"`)
+}
+
+// Limit wraps the query in a subquery limited to n rows.
+func (PostgreSQL) Limit(query string, n int) string {
+	return limitSubquery(query, n)
+}
+
+func (d PostgreSQL) schema() string {
+	if d.Schema == "" {
+		return "public"
+	}
+
+	return d.Schema
+}
+
+// MySQL is the dialect for MySQL and MariaDB databases.
+type MySQL struct{}
+
+// Name returns "MySQL".
+func (MySQL) Name() string {
+	return "MySQL"
+}
+
+// TableNames returns the names of the tables in the current database.
+func (MySQL) TableNames(ctx context.Context, db *sql.DB) ([]string, error) {
+	return queryStrings(ctx, db, `SELECT table_name FROM information_schema.tables
+WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE' ORDER BY table_name`)
+}
+
+// CreateTable returns the result of SHOW CREATE TABLE.
+func (d MySQL) CreateTable(ctx context.Context, db *sql.DB, table string) (string, error) {
+	var name, stmt string
+
+	if err := db.QueryRowContext(ctx, "SHOW CREATE TABLE "+d.QuoteIdentifier(table)).Scan(&name, &stmt); err != nil {
+		return "", err
+	}
+
+	return stmt, nil
+}
+
+// QuoteIdentifier quotes the name using backticks.
+func (MySQL) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, "`")
+}
+
+// Limit wraps the query in a derived table limited to n rows. The
+// columns of the query must have unique names in MySQL.
+func (MySQL) Limit(query string, n int) string {
+	return limitSubquery(query, n)
+}
+
+// limitSubquery wraps the query in a subquery limited to n rows, the query is put on
+// its own lines so that a trailing line comment does not comment out the limit.
+func limitSubquery(query string, n int) string {
+	return fmt.Sprintf("SELECT * FROM (\n%s\n) AS limited LIMIT %d", query, n)
+}
+
+func quoteIdentifier(name, quote string) string {
+	return quote + strings.ReplaceAll(name, quote, quote+quote) + quote
+}
+
+func queryStrings(ctx context.Context, db *sql.DB, query string, args ...any) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+
+	for rows.Next() {
+		var value string
+
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
@@ -0,0 +1,184 @@
+package sqldatabasechain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrQueryNotAllowed is returned when a query is not a single read-only SELECT statement.
+var ErrQueryNotAllowed = errors.New("query is not allowed")
+
+// forbiddenKeywords are keywords that are not allowed anywhere in a query, since
+// they can modify the database in a data-modifying WITH, a SELECT INTO, etc.
+var forbiddenKeywords = map[string]bool{
+	"ALTER":    true,
+	"ATTACH":   true,
+	"COPY":     true,
+	"CREATE":   true,
+	"DELETE":   true,
+	"DETACH":   true,
+	"DROP":     true,
+	"DUMPFILE": true,
+	"EXEC":     true,
+	"EXECUTE":  true,
+	"GRANT":    true,
+	"INSERT":   true,
+	"INTO":     true,
+	"LOCK":     true,
+	"MERGE":    true,
+	"OUTFILE":  true,
+	"PRAGMA":   true,
+	"REVOKE":   true,
+	"TRUNCATE": true,
+	"UPDATE":   true,
+}
+
+// forbiddenFunctions are functions that are not allowed anywhere in a query, since
+// they have side effects such as changing sequences, reading files or killing sessions.
+var forbiddenFunctions = map[string]bool{
+	"BENCHMARK":            true,
+	"DBLINK":               true,
+	"DBLINK_EXEC":          true,
+	"LO_EXPORT":            true,
+	"LO_IMPORT":            true,
+	"LOAD_EXTENSION":       true,
+	"LOAD_FILE":            true,
+	"NEXTVAL":              true,
+	"PG_CANCEL_BACKEND":    true,
+	"PG_LS_DIR":            true,
+	"PG_READ_BINARY_FILE":  true,
+	"PG_READ_FILE":         true,
+	"PG_RELOAD_CONF":       true,
+	"PG_SLEEP":             true,
+	"PG_TERMINATE_BACKEND": true,
+	"SET_CONFIG":           true,
+	"SETVAL":               true,
+	"SLEEP":                true,
+}
+
+// ValidateQuery returns the query without any trailing semicolon if it is a single
+// SELECT statement, optionally starting with WITH, that does not contain any keyword
+// or function that can modify the database. String literals, quoted identifiers and
+// comments are ignored when looking for keywords and semicolons.
+//
+// The validation is a safeguard against mistakes by the provider, the query is also
+// run in a read-only transaction, but neither is a substitute for connecting to the
+// database as a read-only user.
+func ValidateQuery(query string) (string, error) {
+	query = strings.TrimRightFunc(query, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ';'
+	})
+
+	// Backslash escapes in string literals differ between databases,
+	// so the query has to be allowed with and without them.
+	for _, backslashEscapes := range []bool{false, true} {
+		if err := validateCode(maskQuery(query, backslashEscapes)); err != nil {
+			return "", err
+		}
+	}
+
+	return query, nil
+}
+
+func validateCode(code string) error {
+	if strings.Contains(code, ";") {
+		return fmt.Errorf("%w: multiple statements", ErrQueryNotAllowed)
+	}
+
+	// Comments that are not masked are parsed differently by different databases,
+	// e.g. "#" starts a comment and "/*!" is executed in MySQL.
+	for _, comment := range []string{"#", "--", "/*"} {
+		if strings.Contains(code, comment) {
+			return fmt.Errorf("%w: ambiguous comment %q", ErrQueryNotAllowed, comment)
+		}
+	}
+
+	words := strings.FieldsFunc(strings.ToUpper(code), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+
+	if len(words) == 0 {
+		return fmt.Errorf("%w: empty query", ErrQueryNotAllowed)
+	}
+
+	if words[0] != "SELECT" && words[0] != "WITH" {
+		return fmt.Errorf("%w: not a SELECT statement", ErrQueryNotAllowed)
+	}
+
+	for _, word := range words {
+		if forbiddenKeywords[word] || forbiddenFunctions[word] {
+			return fmt.Errorf("%w: contains %s", ErrQueryNotAllowed, word)
+		}
+	}
+
+	return nil
+}
+
+// maskQuery replaces string literals, quoted identifiers and comments with spaces. Comments that
+// are not parsed the same by all databases, "#", "/*!" and "--" not followed by whitespace, are
+// left as is.
+func maskQuery(query string, backslashEscapes bool) string {
+	var (
+		b = []byte(query)
+		i = 0
+	)
+
+	mask := func(from, to int) {
+		for j := from; j < to && j < len(b); j++ {
+			b[j] = ' '
+		}
+	}
+
+	for i < len(b) {
+		switch {
+		case b[i] == '\'' || b[i] == '"' || b[i] == '`':
+			quote, end := b[i], i+1
+
+			for end < len(b) {
+				if b[end] == quote {
+					if end+1 < len(b) && b[end+1] == quote {
+						end += 2
+						continue
+					}
+
+					break
+				}
+
+				if backslashEscapes && b[end] == '\\' {
+					end++
+				}
+
+				end++
+			}
+
+			mask(i, end+1)
+			i = end + 1
+		case b[i] == '-' && i+2 < len(b) && b[i+1] == '-' && isSpace(b[i+2]):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(b) - i
+			}
+
+			mask(i, i+end)
+			i += end
+		case b[i] == '/' && i+1 < len(b) && b[i+1] == '*' && (i+2 >= len(b) || b[i+2] != '!'):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(b) - i - 2
+			}
+
+			mask(i, i+end+4)
+			i += end + 4
+		default:
+			i++
+		}
+	}
+
+	return string(b)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,205 @@
+package sqldatabasechain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Chain = Chain{}
+
+const (
+	defaultInputKey  = "question"
+	defaultOutputKey = "text"
+	defaultQueryKey  = "sql_query"
+	defaultResultKey = "sql_result"
+	defaultTopK      = 5
+)
+
+const queryTemplate = `Given an input question, create a syntactically correct {{.dialect}} query to run.
+Unless the question asks for a specific number of results, query for at most {{.top_k}} results.
+Never query for all columns of a table, only the columns needed to answer the question.
+Only use the tables and columns described below. Only SELECT statements are allowed.
+
+{{.table_info}}
+
+Respond with only the SQL query, without any explanation or formatting.
+
+Question: {{.input}}
+SQLQuery:`
+
+const answerTemplate = `Given an input question, the SQL query that was run, and its result, answer the question.
+Only use the result to answer the question. If the result is empty, say that no data was found.
+
+Question: {{.input}}
+SQLQuery: {{.query}}
+SQLResult:
+{{.result}}
+Answer:`
+
+// Chain answers questions about a SQL database. It asks the provider for a
+// query using the table info of the database, validates the query using
+// ValidateQuery, runs it, and then asks the provider to answer the question
+// from the result.
+type Chain struct {
+	// Provider is used to write the query and to answer the question.
+	Provider llm.Provider
+
+	// Database is the database queried.
+	Database *Database
+
+	// QueryPrompt is used to write the query, it is given the
+	// "input", "dialect", "table_info" and "top_k" values.
+	QueryPrompt llm.PromptFormatter
+
+	// AnswerPrompt is used to answer the question, it is given
+	// the "input", "query" and "result" values.
+	AnswerPrompt llm.PromptFormatter
+
+	// InputKey is the key of the question, by default "question".
+	InputKey string
+
+	// OutputKey is the key of the answer, by default "text".
+	OutputKey string
+
+	// TopK is the maximum number of rows returned by the query, by default 5.
+	TopK int
+
+	// ReturnQuery makes the chain return the query in the "sql_query"
+	// key, and its result in the "sql_result" key.
+	ReturnQuery bool
+}
+
+// New creates a new SQL database chain.
+func New(provider llm.Provider, database *Database, options ...func(*Chain)) Chain {
+	c := Chain{
+		Provider:     provider,
+		Database:     database,
+		QueryPrompt:  llm.GoTemplate(queryTemplate, []string{"input", "dialect", "table_info", "top_k"}),
+		AnswerPrompt: llm.GoTemplate(answerTemplate, []string{"input", "query", "result"}),
+		InputKey:     defaultInputKey,
+		OutputKey:    defaultOutputKey,
+		TopK:         defaultTopK,
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	return c
+}
+
+// Call writes a query for the question, runs it, and answers the question from the result.
+func (c Chain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
+	input, ok := values[c.InputKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", llm.ErrInvalidInputValues, llm.ErrInputValuesWrongType)
+	}
+
+	tableInfo, err := c.Database.TableInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := c.QueryPrompt.FormatPrompt(map[string]any{
+		"input":      input,
+		"dialect":    c.Database.Dialect.Name(),
+		"table_info": tableInfo,
+		"top_k":      c.TopK,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contentOptions := llm.ChainToContentOptions(options...)
+
+	queryOptions := append([]llm.ContentOption{
+		llm.WithTemperature(0),
+		llm.WithStopWords([]string{"\nSQLResult:"}),
+	}, contentOptions...)
+
+	output, err := llm.Call(ctx, c.Provider, prompt.String(), queryOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := ValidateQuery(ParseQuery(output))
+	if err != nil {
+		return nil, err
+	}
+
+	columns, rows, err := c.Database.Query(ctx, query, c.TopK)
+	if err != nil {
+		return nil, err
+	}
+
+	result := FormatRows(columns, rows)
+
+	prompt, err = c.AnswerPrompt.FormatPrompt(map[string]any{
+		"input":  input,
+		"query":  query,
+		"result": result,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	answerOptions := append([]llm.ContentOption{llm.WithTemperature(0)}, contentOptions...)
+
+	answer, err := llm.Call(ctx, c.Provider, prompt.String(), answerOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := map[string]any{
+		c.OutputKey: strings.TrimSpace(answer),
+	}
+
+	if c.ReturnQuery {
+		outputs[defaultQueryKey] = query
+		outputs[defaultResultKey] = result
+	}
+
+	return outputs, nil
+}
+
+// Memory returns empty memory.
+func (c Chain) Memory() llm.Memory {
+	return llm.EmptyMemory{}
+}
+
+// InputKeys returns the input key, by default "question".
+func (c Chain) InputKeys() []string {
+	return []string{c.InputKey}
+}
+
+// OutputKeys returns the output key, by default "text", and
+// "sql_query" and "sql_result" if ReturnQuery is set.
+func (c Chain) OutputKeys() []string {
+	if c.ReturnQuery {
+		return []string{c.OutputKey, defaultQueryKey, defaultResultKey}
+	}
+
+	return []string{c.OutputKey}
+}
+
+// ParseQuery returns the query in the output of the provider, removing any
+// "SQLQuery:" prefix, "SQLResult:" suffix and markdown code fence.
+func ParseQuery(output string) string {
+	if _, after, ok := strings.Cut(output, "SQLQuery:"); ok {
+		output = after
+	}
+
+	output, _, _ = strings.Cut(output, "SQLResult:")
+	output = strings.TrimSpace(output)
+
+	if strings.HasPrefix(output, "```") {
+		output = strings.TrimPrefix(output, "```")
+		output = strings.TrimPrefix(output, "sql")
+		output, _, _ = strings.Cut(output, "```")
+	}
+
+	return strings.TrimSpace(output)
+}
@@ -0,0 +1,263 @@
+package sqldatabasechain
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+// fakeResult is the result of a query to the fake database.
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// fakeDB is a database/sql/driver connector that returns results for queries containing known strings.
+type fakeDB struct {
+	results map[string]fakeResult
+	queries *[]string
+	txs     *[]*fakeTx
+}
+
+func (f fakeDB) Connect(context.Context) (driver.Conn, error) { return fakeConn(f), nil }
+func (f fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn fakeDB
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c fakeConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx := &fakeTx{readOnly: opts.ReadOnly}
+
+	if c.txs != nil {
+		*c.txs = append(*c.txs, tx)
+	}
+
+	return tx, nil
+}
+
+type fakeTx struct {
+	readOnly   bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Commit() error   { return errors.New("not supported") }
+func (tx *fakeTx) Rollback() error { tx.rolledBack = true; return nil }
+
+func (c fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	*c.queries = append(*c.queries, query)
+
+	for substr, result := range c.results {
+		if strings.Contains(query, substr) {
+			return &fakeRows{result: result}, nil
+		}
+	}
+
+	return nil, errors.New("unexpected query: " + query)
+}
+
+type fakeRows struct {
+	result fakeResult
+	i      int
+}
+
+func (r *fakeRows) Columns() []string { return r.result.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.result.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.result.rows[r.i])
+	r.i++
+
+	return nil
+}
+
+func textOf(messages []llm.Message) string {
+	var sb strings.Builder
+
+	for _, m := range messages {
+		for _, p := range m.Parts {
+			if t, ok := p.(llm.TextContent); ok {
+				sb.WriteString(t.Text)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func TestChain(t *testing.T) {
+	var (
+		queries []string
+		prompts []string
+		models  []string
+		txs     []*fakeTx
+	)
+
+	db := sql.OpenDB(fakeDB{
+		queries: &queries,
+		txs:     &txs,
+		results: map[string]fakeResult{
+			"SELECT name FROM sqlite_master": {
+				columns: []string{"name"},
+				rows:    [][]driver.Value{{"orders"}},
+			},
+			"SELECT sql FROM sqlite_master": {
+				columns: []string{"sql"},
+				rows:    [][]driver.Value{{"CREATE TABLE orders (id INTEGER, total REAL)"}},
+			},
+			`SELECT * FROM "orders"`: {
+				columns: []string{"id", "total"},
+				rows:    [][]driver.Value{{int64(1), 9.5}, {int64(2), 20.0}, {int64(3), nil}, {int64(4), 1.0}},
+			},
+			"SELECT SUM(total)": {
+				columns: []string{"sum"},
+				rows:    [][]driver.Value{{30.5}},
+			},
+		},
+	})
+
+	provider := mock.Provider{
+		GenerateContentFunc: func(_ context.Context, messages []llm.Message, options ...llm.ContentOption) (*llm.ContentResponse, error) {
+			opts := llm.ContentOptions{}
+
+			for _, opt := range options {
+				opt(&opts)
+			}
+
+			prompts = append(prompts, textOf(messages))
+			models = append(models, opts.Model)
+
+			content := "The total is 30.5."
+
+			if len(prompts) == 1 {
+				content = "```sql\nSELECT SUM(total) FROM orders;\n```"
+			}
+
+			return &llm.ContentResponse{Choices: []*llm.ContentChoice{{Content: content}}}, nil
+		},
+	}
+
+	c := New(provider, NewDatabase(db, SQLite{}), func(c *Chain) {
+		c.ReturnQuery = true
+	})
+
+	got, err := llm.ChainCall(context.Background(), c, map[string]any{"question": "What is the total of all orders?"},
+		llm.ChainWithModel("gpt-4o"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := got["text"], "The total is 30.5."; got != want {
+		t.Fatalf("text = %q, want %q", got, want)
+	}
+
+	if got, want := got["sql_query"], "SELECT SUM(total) FROM orders"; got != want {
+		t.Fatalf("sql_query = %q, want %q", got, want)
+	}
+
+	if got, want := queries[len(queries)-1], "SELECT * FROM (\nSELECT SUM(total) FROM orders\n) AS limited LIMIT 5"; got != want {
+		t.Fatalf("query = %q, want %q", got, want)
+	}
+
+	for _, tx := range txs {
+		if !tx.readOnly || !tx.rolledBack {
+			t.Fatalf("tx = %+v, want read-only and rolled back", tx)
+		}
+	}
+
+	if want := "3 rows from orders table:\nid\ttotal\n1\t9.5\n2\t20\n3\tNULL\n*/"; !strings.Contains(prompts[0], want) {
+		t.Fatalf("prompts[0] = %q, want it to contain %q", prompts[0], want)
+	}
+
+	if want := "SQLResult:\nsum\n30.5\n"; !strings.Contains(prompts[1], want) {
+		t.Fatalf("prompts[1] = %q, want it to contain %q", prompts[1], want)
+	}
+
+	if want := []string{"gpt-4o", "gpt-4o"}; !slices.Equal(models, want) {
+		t.Fatalf("models = %q, want %q", models, want)
+	}
+}
+
+func TestChainQueryNotAllowed(t *testing.T) {
+	var queries []string
+
+	db := sql.OpenDB(fakeDB{
+		queries: &queries,
+		results: map[string]fakeResult{
+			"SELECT name FROM sqlite_master": {columns: []string{"name"}},
+		},
+	})
+
+	provider := mock.Provider{
+		GenerateContentFunc: func(context.Context, []llm.Message, ...llm.ContentOption) (*llm.ContentResponse, error) {
+			return &llm.ContentResponse{Choices: []*llm.ContentChoice{{Content: "DROP TABLE orders"}}}, nil
+		},
+	}
+
+	_, err := llm.ChainCall(context.Background(), New(provider, NewDatabase(db, SQLite{})),
+		map[string]any{"question": "Remove all orders"})
+	if !errors.Is(err, ErrQueryNotAllowed) {
+		t.Fatalf("err = %v, want %v", err, ErrQueryNotAllowed)
+	}
+
+	if got, want := len(queries), 1; got != want {
+		t.Fatalf("len(queries) = %d, want %d", got, want)
+	}
+}
+
+func TestValidateQuery(t *testing.T) {
+	for _, tt := range []struct {
+		query string
+		want  string
+		ok    bool
+	}{
+		{"SELECT * FROM orders;  ", "SELECT * FROM orders", true},
+		{"with t AS (SELECT 1) SELECT * FROM t", "with t AS (SELECT 1) SELECT * FROM t", true},
+		{"SELECT 'a; DROP TABLE orders' AS s", "SELECT 'a; DROP TABLE orders' AS s", true},
+		{`SELECT "update" FROM orders -- delete`, `SELECT "update" FROM orders -- delete`, true},
+		{"SELECT 1; DROP TABLE orders", "", false},
+		{"DELETE FROM orders", "", false},
+		{"WITH d AS (DELETE FROM orders RETURNING *) SELECT * FROM d", "", false},
+		{"SELECT * INTO backup FROM orders", "", false},
+		{`SELECT '\' ; DROP TABLE orders; --'`, "", false},
+		{"/* SELECT */ UPDATE orders SET total = 0", "", false},
+		{"SELECT 1 # '\nINTO OUTFILE '/tmp/x'", "", false},
+		{"SELECT 1 # '\n; DROP TABLE t; -- '", "", false},
+		{"SELECT 1 /*!50000 INTO OUTFILE '/tmp/x' */", "", false},
+		{"SELECT 1 --'\n; DROP TABLE t; SELECT ''", "", false},
+		{"SELECT load_extension('/tmp/evil.so')", "", false},
+		{"SELECT setval('s', 1)", "", false},
+		{"SELECT pg_terminate_backend(pid) FROM pg_stat_activity", "", false},
+		{"SELECT 1 -- comment", "SELECT 1 -- comment", true},
+		{"", "", false},
+	} {
+		got, err := ValidateQuery(tt.query)
+
+		if tt.ok && err != nil {
+			t.Fatalf("ValidateQuery(%q) unexpected error: %v", tt.query, err)
+		}
+
+		if !tt.ok && !errors.Is(err, ErrQueryNotAllowed) {
+			t.Fatalf("ValidateQuery(%q) err = %v, want %v", tt.query, err, ErrQueryNotAllowed)
+		}
+
+		if got != tt.want {
+			t.Fatalf("ValidateQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
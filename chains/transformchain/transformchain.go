@@ -0,0 +1,78 @@
+package transformchain
+
+import (
+	"context"
+
+	"github.com/peterhellberg/llm"
+)
+
+var (
+	_ llm.Chain       = Chain{}
+	_ llm.ChainHooker = Chain{}
+)
+
+// Func transforms the input values into the output values.
+type Func func(ctx context.Context, values map[string]any) (map[string]any, error)
+
+// Chain wraps a Func with declared input and output keys, so that deterministic
+// steps such as cleaning text or fetching records can be used wherever an
+// llm.Chain is used, e.g. in a sequentialchain.Chain.
+type Chain struct {
+	// Func is called with a copy of the input values.
+	Func Func
+
+	// Inputs are the input keys of the Chain.
+	Inputs []string
+
+	// Outputs are the output keys returned by the Func.
+	Outputs []string
+
+	// Hooks are returned by ChainHooks, may be nil.
+	Hooks llm.ChainHooks
+}
+
+// New creates a new transform chain that calls fn.
+func New(inputKeys, outputKeys []string, fn Func, options ...func(*Chain)) Chain {
+	c := Chain{
+		Func:    fn,
+		Inputs:  inputKeys,
+		Outputs: outputKeys,
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	return c
+}
+
+// Call calls the Func with a copy of the input values.
+func (c Chain) Call(ctx context.Context, values map[string]any, _ ...llm.ChainOption) (map[string]any, error) {
+	inputs := make(map[string]any, len(values))
+
+	for key, value := range values {
+		inputs[key] = value
+	}
+
+	return c.Func(ctx, inputs)
+}
+
+// Memory returns empty memory.
+func (c Chain) Memory() llm.Memory {
+	return llm.EmptyMemory{}
+}
+
+// ChainHooks returns the hooks of the chain.
+func (c Chain) ChainHooks() llm.ChainHooks {
+	return c.Hooks
+}
+
+// InputKeys returns the input keys.
+func (c Chain) InputKeys() []string {
+	return append([]string{}, c.Inputs...)
+}
+
+// OutputKeys returns the output keys.
+func (c Chain) OutputKeys() []string {
+	return append([]string{}, c.Outputs...)
+}
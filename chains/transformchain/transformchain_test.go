@@ -0,0 +1,58 @@
+package transformchain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/chains/sequentialchain"
+	"github.com/peterhellberg/llm/mock"
+)
+
+func TestChain(t *testing.T) {
+	var started, ended bool
+
+	trim := New([]string{"text"}, []string{"trimmed"}, func(_ context.Context, values map[string]any) (map[string]any, error) {
+		return map[string]any{"trimmed": strings.TrimSpace(values["text"].(string))}, nil
+	}, func(c *Chain) {
+		c.Hooks = mock.Hooks{
+			ChainStartFunc: func(context.Context, map[string]any) { started = true },
+			ChainEndFunc:   func(context.Context, map[string]any) { ended = true },
+		}
+	})
+
+	upper := New([]string{"trimmed"}, []string{"upper"}, func(_ context.Context, values map[string]any) (map[string]any, error) {
+		return map[string]any{"upper": strings.ToUpper(values["trimmed"].(string))}, nil
+	})
+
+	c, err := sequentialchain.New([]llm.Chain{trim, upper}, []string{"text"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := llm.ChainRun(context.Background(), c, "  hello  ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "HELLO"; got != want {
+		t.Fatalf("got = %q, want %q", got, want)
+	}
+
+	if !started || !ended {
+		t.Fatalf("started = %v, ended = %v, want both true", started, ended)
+	}
+}
+
+func TestChainMissingOutput(t *testing.T) {
+	c := New([]string{"text"}, []string{"out"}, func(context.Context, map[string]any) (map[string]any, error) {
+		return map[string]any{}, nil
+	})
+
+	_, err := llm.ChainCall(context.Background(), c, map[string]any{"text": "hello"})
+	if !errors.Is(err, llm.ErrInvalidOutputValues) {
+		t.Fatalf("err = %v, want %v", err, llm.ErrInvalidOutputValues)
+	}
+}
@@ -11,6 +11,8 @@ import (
 var (
 	_ ChatMessage = AIChatMessage{}
 	_ ChatMessage = HumanChatMessage{}
+	_ ChatMessage = HumanPartsChatMessage{}
+	_ MultiPart   = HumanPartsChatMessage{}
 	_ ChatMessage = SystemChatMessage{}
 	_ ChatMessage = GenericChatMessage{}
 	_ ChatMessage = ToolChatMessage{}
@@ -88,15 +90,37 @@ func (ai AIChatMessage) MessageFunctionCall() *FunctionCall { return ai.Function
 
 // HumanChatMessage is a message sent by a human.
 type HumanChatMessage struct {
-	Content string
+	Content string `json:"content"`
 }
 
 func (human HumanChatMessage) Type() ChatMessageType  { return ChatMessageTypeHuman }
 func (human HumanChatMessage) MessageContent() string { return human.Content }
 
+// HumanPartsChatMessage is a chat message sent by a human with content parts other
+// than text, such as images. The parts are sent as is to the provider, see MultiPart.
+type HumanPartsChatMessage struct {
+	Parts []ContentPart `json:"parts"`
+}
+
+func (human HumanPartsChatMessage) Type() ChatMessageType       { return ChatMessageTypeHuman }
+func (human HumanPartsChatMessage) MessageParts() []ContentPart { return human.Parts }
+
+// MessageContent returns the text parts joined by newlines.
+func (human HumanPartsChatMessage) MessageContent() string {
+	var texts []string
+
+	for _, part := range human.Parts {
+		if text, ok := part.(TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+
+	return strings.Join(texts, "\n")
+}
+
 // SystemChatMessage is a chat message representing information that should be instructions to the AI system.
 type SystemChatMessage struct {
-	Content string
+	Content string `json:"content"`
 }
 
 func (system SystemChatMessage) Type() ChatMessageType  { return ChatMessageTypeSystem }
@@ -104,9 +128,9 @@ func (system SystemChatMessage) MessageContent() string { return system.Content
 
 // GenericChatMessage is a chat message with an arbitrary speaker.
 type GenericChatMessage struct {
-	Content string
-	Role    string
-	Name    string
+	Content string `json:"content"`
+	Role    string `json:"role"`
+	Name    string `json:"name,omitempty"`
 }
 
 func (m GenericChatMessage) Type() ChatMessageType  { return ChatMessageTypeGeneric }
@@ -119,12 +143,185 @@ type ToolChatMessage struct {
 	CallID string `json:"tool_call_id"`
 	// Content is the content of the tool message.
 	Content string `json:"content"`
+	// Name is the name of the tool that was called.
+	Name string `json:"name,omitempty"`
 }
 
 func (tool ToolChatMessage) Type() ChatMessageType  { return ChatMessageTypeTool }
 func (tool ToolChatMessage) MessageContent() string { return tool.Content }
 func (tool ToolChatMessage) ID() string             { return tool.CallID }
 
+// MarshalJSON encodes the message with its type, see UnmarshalChatMessage.
+func (ai AIChatMessage) MarshalJSON() ([]byte, error) {
+	type alias AIChatMessage
+
+	return marshalChatMessage(ai.Type(), alias(ai))
+}
+
+// MarshalJSON encodes the message with its type, see UnmarshalChatMessage.
+func (human HumanChatMessage) MarshalJSON() ([]byte, error) {
+	type alias HumanChatMessage
+
+	return marshalChatMessage(human.Type(), alias(human))
+}
+
+// MarshalJSON encodes the message with its type, and each part
+// as a discriminated union, see UnmarshalChatMessage.
+func (human HumanPartsChatMessage) MarshalJSON() ([]byte, error) {
+	parts := make([]json.RawMessage, 0, len(human.Parts))
+
+	for _, part := range human.Parts {
+		data, err := MarshalContentPart(part)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, data)
+	}
+
+	return marshalChatMessage(human.Type(), struct {
+		Parts []json.RawMessage `json:"parts"`
+	}{parts})
+}
+
+// UnmarshalJSON decodes a message encoded by MarshalJSON.
+func (human *HumanPartsChatMessage) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Parts []json.RawMessage `json:"parts"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	parts := make([]ContentPart, 0, len(v.Parts))
+
+	for _, raw := range v.Parts {
+		part, err := UnmarshalContentPart(raw)
+		if err != nil {
+			return err
+		}
+
+		parts = append(parts, part)
+	}
+
+	human.Parts = parts
+
+	return nil
+}
+
+// MarshalJSON encodes the message with its type, see UnmarshalChatMessage.
+func (system SystemChatMessage) MarshalJSON() ([]byte, error) {
+	type alias SystemChatMessage
+
+	return marshalChatMessage(system.Type(), alias(system))
+}
+
+// MarshalJSON encodes the message with its type, see UnmarshalChatMessage.
+func (m GenericChatMessage) MarshalJSON() ([]byte, error) {
+	type alias GenericChatMessage
+
+	return marshalChatMessage(m.Type(), alias(m))
+}
+
+// MarshalJSON encodes the message with its type, see UnmarshalChatMessage.
+func (tool ToolChatMessage) MarshalJSON() ([]byte, error) {
+	type alias ToolChatMessage
+
+	return marshalChatMessage(tool.Type(), alias(tool))
+}
+
+// marshalChatMessage encodes v with an additional "type" field.
+func marshalChatMessage(t ChatMessageType, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if fields["type"], err = json.Marshal(t); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalChatMessage decodes a chat message encoded by one of the
+// MarshalJSON methods of the ChatMessage implementations in this package.
+func UnmarshalChatMessage(data []byte) (ChatMessage, error) {
+	var typed struct {
+		Type  ChatMessageType `json:"type"`
+		Parts json.RawMessage `json:"parts"`
+	}
+
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	switch typed.Type {
+	case ChatMessageTypeAI:
+		return unmarshalChatMessage[AIChatMessage](data)
+	case ChatMessageTypeHuman:
+		if typed.Parts != nil {
+			var m HumanPartsChatMessage
+
+			if err := json.Unmarshal(data, &m); err != nil {
+				return nil, err
+			}
+
+			return m, nil
+		}
+
+		return unmarshalChatMessage[HumanChatMessage](data)
+	case ChatMessageTypeSystem:
+		return unmarshalChatMessage[SystemChatMessage](data)
+	case ChatMessageTypeGeneric:
+		return unmarshalChatMessage[GenericChatMessage](data)
+	case ChatMessageTypeTool:
+		return unmarshalChatMessage[ToolChatMessage](data)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnexpectedChatMessageType, typed.Type)
+	}
+}
+
+func unmarshalChatMessage[T ChatMessage](data []byte) (ChatMessage, error) {
+	var m T
+
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// UnmarshalChatMessages decodes a JSON array of chat messages, such as
+// the encoding of a []ChatMessage returned by ChatMessageHistory.Messages.
+func UnmarshalChatMessages(data []byte) ([]ChatMessage, error) {
+	var raw []json.RawMessage
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	messages := make([]ChatMessage, 0, len(raw))
+
+	for _, r := range raw {
+		m, err := UnmarshalChatMessage(r)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, m)
+	}
+
+	return messages, nil
+}
+
 // BufferString gets the buffer string of messages.
 func BufferString(messages []ChatMessage, humanPrefix string, aiPrefix string) (string, error) {
 	result := []string{}
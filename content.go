@@ -40,6 +40,23 @@ func ImageURLPart(url string) ImageURLContent {
 	}
 }
 
+// AudioPart creates a new AudioContent from the given format (e.g. "wav") and audio data.
+func AudioPart(format string, data []byte) AudioContent {
+	return AudioContent{
+		Format: format,
+		Data:   data,
+	}
+}
+
+// FilePart creates a new FileContent from the given filename, MIME type and data.
+func FilePart(filename, mime string, data []byte) FileContent {
+	return FileContent{
+		Filename: filename,
+		MIMEType: mime,
+		Data:     data,
+	}
+}
+
 // ImageURLWithDetailPart creates a new ImageURLContent from the given URL and detail.
 func ImageURLWithDetailPart(url string, detail string) ImageURLContent {
 	return ImageURLContent{
@@ -89,6 +106,59 @@ func (bc BinaryContent) String() string {
 
 func (BinaryContent) isPart() {}
 
+// AudioContent is audio input, such as a recording of speech.
+type AudioContent struct {
+	// Format is the format of the audio data, e.g. "wav" or "mp3".
+	Format string `json:"format"`
+	// Data is the encoded audio data.
+	Data []byte `json:"data"`
+}
+
+func (AudioContent) isPart() {}
+
+// FileContent is a file attachment, such as a PDF document.
+type FileContent struct {
+	// Filename is the name of the file, e.g. "report.pdf".
+	Filename string `json:"filename,omitempty"`
+	// MIMEType is the MIME type of the file, e.g. "application/pdf".
+	MIMEType string `json:"mime_type,omitempty"`
+	// Data is the content of the file.
+	Data []byte `json:"data,omitempty"`
+	// FileID is the ID of a file previously uploaded to the provider, used instead of Data.
+	FileID string `json:"file_id,omitempty"`
+}
+
+func (fc FileContent) String() string {
+	if fc.FileID != "" {
+		return fc.FileID
+	}
+
+	return "data:" + fc.MIMEType + ";base64," + base64.StdEncoding.EncodeToString(fc.Data)
+}
+
+func (FileContent) isPart() {}
+
+// AudioOutputContent is audio generated by the model.
+// It can be included in an AI message to refer to the audio in later turns.
+type AudioOutputContent struct {
+	// ID is the provider specific identifier of the audio.
+	ID string `json:"id"`
+	// Format is the format of the audio data, e.g. "wav" or "mp3".
+	Format string `json:"format,omitempty"`
+	// Data is the encoded audio data.
+	Data []byte `json:"data,omitempty"`
+	// Transcript is the transcript of the audio.
+	Transcript string `json:"transcript,omitempty"`
+	// ExpiresAt is the Unix timestamp for when the audio can no longer be referred to.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
+
+func (aoc AudioOutputContent) String() string {
+	return aoc.Transcript
+}
+
+func (AudioOutputContent) isPart() {}
+
 // FunctionCall is the name and arguments of a function call.
 type FunctionCall struct {
 	// The name of the function to call.
@@ -146,6 +216,9 @@ type ContentChoice struct {
 
 	// ToolCalls is a list of tool calls the model asks to invoke.
 	ToolCalls []ToolCall
+
+	// Audio is non-nil when the model responds with audio, see WithAudioOutput.
+	Audio *AudioOutputContent
 }
 
 // ContentOption is a function that configures Options.
@@ -198,6 +271,19 @@ type ContentOptions struct {
 	// Metadata is a map of metadata to include in the request.
 	// The meaning of this field is specific to the backend in use.
 	Metadata map[string]any `json:"metadata,omitempty"`
+
+	// Modalities is the list of output types the model should generate, e.g. "text" and "audio".
+	Modalities []string `json:"modalities,omitempty"`
+	// Audio configures the audio output, used when Modalities contains "audio".
+	Audio *AudioOutputOptions `json:"audio,omitempty"`
+}
+
+// AudioOutputOptions configures the audio generated by the model.
+type AudioOutputOptions struct {
+	// Voice is the voice the model should use, e.g. "alloy".
+	Voice string `json:"voice"`
+	// Format is the format of the audio data, e.g. "wav" or "mp3".
+	Format string `json:"format"`
 }
 
 // Tool is a tool that can be used by the model.
@@ -387,3 +473,15 @@ func WithMetadata(metadata map[string]any) ContentOption {
 		o.Metadata = metadata
 	}
 }
+
+// WithAudioOutput will add an option to have the model respond with
+// both text and audio, using the given voice and audio format.
+func WithAudioOutput(voice, format string) ContentOption {
+	return func(o *ContentOptions) {
+		o.Modalities = []string{"text", "audio"}
+		o.Audio = &AudioOutputOptions{
+			Voice:  voice,
+			Format: format,
+		}
+	}
+}
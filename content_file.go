@@ -0,0 +1,108 @@
+package llm
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// extensionTypes are used for common extensions that might be missing from
+// the MIME types known by the system.
+var extensionTypes = map[string]string{
+	".flac": "audio/flac",
+	".m4a":  "audio/mp4",
+	".md":   "text/markdown",
+	".mp3":  "audio/mpeg",
+	".oga":  "audio/ogg",
+	".ogg":  "audio/ogg",
+	".pdf":  "application/pdf",
+	".wav":  "audio/wav",
+	".webm": "audio/webm",
+}
+
+// audioFormats maps audio MIME types to the format names used by providers.
+var audioFormats = map[string]string{
+	"audio/mpeg":  "mp3",
+	"audio/mp3":   "mp3",
+	"audio/wav":   "wav",
+	"audio/wave":  "wav",
+	"audio/x-wav": "wav",
+	"audio/mp4":   "m4a",
+	"audio/x-m4a": "m4a",
+	"audio/aiff":  "aiff",
+}
+
+// PartFromFile reads the file at path and creates a content part from it, see PartFromReader.
+func PartFromFile(path string) (ContentPart, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return PartFromReader(f, filepath.Base(path))
+}
+
+// PartFromReader reads all data from r and creates a content part from it.
+// The MIME type is sniffed from the data, falling back to the extension of
+// filename when the data is not recognized. Images become BinaryContent,
+// audio becomes AudioContent and everything else becomes FileContent.
+func PartFromReader(r io.Reader, filename string) (ContentPart, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := DetectMIMEType(data, filename)
+
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return BinaryPart(mimeType, data), nil
+	case strings.HasPrefix(mimeType, "audio/"):
+		return AudioPart(AudioFormat(mimeType), data), nil
+	default:
+		return FilePart(filename, mimeType, data), nil
+	}
+}
+
+// DetectMIMEType returns the MIME type of the data, without any parameters.
+// The extension of filename is used if the data is not recognized.
+func DetectMIMEType(data []byte, filename string) string {
+	sniffed := mediaType(http.DetectContentType(data))
+
+	if sniffed != "application/octet-stream" && sniffed != "text/plain" {
+		return sniffed
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	if t, ok := extensionTypes[ext]; ok {
+		return t
+	}
+
+	if t := mediaType(mime.TypeByExtension(ext)); t != "" {
+		return t
+	}
+
+	return sniffed
+}
+
+// AudioFormat returns the audio format name for the MIME type, e.g. "mp3" for "audio/mpeg".
+func AudioFormat(mimeType string) string {
+	mimeType = mediaType(mimeType)
+
+	if format, ok := audioFormats[mimeType]; ok {
+		return format
+	}
+
+	return strings.TrimPrefix(mimeType, "audio/")
+}
+
+func mediaType(contentType string) string {
+	t, _, _ := strings.Cut(contentType, ";")
+
+	return strings.TrimSpace(t)
+}
@@ -0,0 +1,53 @@
+package llm_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+)
+
+func TestPartFromReader(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+	pdf := []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	wav := []byte("RIFF\x24\x00\x00\x00WAVEfmt ")
+	mp3 := []byte{0xff, 0xfb, 0x90, 0x64, 0x00}
+
+	for _, tt := range []struct {
+		name     string
+		data     []byte
+		filename string
+		want     llm.ContentPart
+	}{
+		{"png", png, "image", llm.BinaryPart("image/png", png)},
+		{"pdf", pdf, "report.pdf", llm.FilePart("report.pdf", "application/pdf", pdf)},
+		{"wav", wav, "speech", llm.AudioPart("wav", wav)},
+		{"mp3 by extension", mp3, "speech.mp3", llm.AudioPart("mp3", mp3)},
+		{"unknown", mp3, "data", llm.FilePart("data", "application/octet-stream", mp3)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := llm.PartFromReader(bytes.NewReader(tt.data), tt.filename)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("part = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAudioFormat(t *testing.T) {
+	for mimeType, want := range map[string]string{
+		"audio/mpeg":           "mp3",
+		"audio/wave":           "wav",
+		"audio/flac":           "flac",
+		"audio/ogg; codecs=xx": "ogg",
+	} {
+		if got := llm.AudioFormat(mimeType); got != want {
+			t.Fatalf("AudioFormat(%q) = %q, want %q", mimeType, got, want)
+		}
+	}
+}
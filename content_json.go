@@ -0,0 +1,123 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Content part types used in the JSON representation of content parts.
+const (
+	ContentPartTypeText             = "text"
+	ContentPartTypeImageURL         = "image_url"
+	ContentPartTypeBinary           = "binary"
+	ContentPartTypeAudio            = "audio"
+	ContentPartTypeFile             = "file"
+	ContentPartTypeAudioOutput      = "audio_output"
+	ContentPartTypeToolCall         = "tool_call"
+	ContentPartTypeToolCallResponse = "tool_call_response"
+)
+
+// contentPartJSON is the discriminated union used to serialize content parts.
+type contentPartJSON struct {
+	Type             string              `json:"type"`
+	Text             string              `json:"text,omitempty"`
+	ImageURL         *ImageURLContent    `json:"image_url,omitempty"`
+	Binary           *binaryJSON         `json:"binary,omitempty"`
+	Audio            *AudioContent       `json:"audio,omitempty"`
+	File             *FileContent        `json:"file,omitempty"`
+	AudioOutput      *AudioOutputContent `json:"audio_output,omitempty"`
+	ToolCall         *ToolCall           `json:"tool_call,omitempty"`
+	ToolCallResponse *ToolCallResponse   `json:"tool_call_response,omitempty"`
+}
+
+type binaryJSON struct {
+	MIMEType string `json:"mime_type"`
+	Data     []byte `json:"data"`
+}
+
+// MarshalContentPart returns the JSON encoding of the content part, with
+// a "type" field used to determine the type of the part when unmarshaling.
+func MarshalContentPart(part ContentPart) ([]byte, error) {
+	var v contentPartJSON
+
+	switch p := part.(type) {
+	case TextContent:
+		v = contentPartJSON{Type: ContentPartTypeText, Text: p.Text}
+	case ImageURLContent:
+		v = contentPartJSON{Type: ContentPartTypeImageURL, ImageURL: &p}
+	case BinaryContent:
+		v = contentPartJSON{Type: ContentPartTypeBinary, Binary: &binaryJSON{MIMEType: p.MIMEType, Data: p.Data}}
+	case AudioContent:
+		v = contentPartJSON{Type: ContentPartTypeAudio, Audio: &p}
+	case FileContent:
+		v = contentPartJSON{Type: ContentPartTypeFile, File: &p}
+	case AudioOutputContent:
+		v = contentPartJSON{Type: ContentPartTypeAudioOutput, AudioOutput: &p}
+	case ToolCall:
+		v = contentPartJSON{Type: ContentPartTypeToolCall, ToolCall: &p}
+	case ToolCallResponse:
+		v = contentPartJSON{Type: ContentPartTypeToolCallResponse, ToolCallResponse: &p}
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnexpectedContentPartType, part)
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalContentPart parses JSON encoded by MarshalContentPart into a content part.
+func UnmarshalContentPart(data []byte) (ContentPart, error) {
+	var v contentPartJSON
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	switch v.Type {
+	case ContentPartTypeText:
+		return TextContent{Text: v.Text}, nil
+	case ContentPartTypeImageURL:
+		if v.ImageURL == nil {
+			return nil, fmt.Errorf("%w: missing %s", ErrInvalidContentPart, v.Type)
+		}
+
+		return *v.ImageURL, nil
+	case ContentPartTypeBinary:
+		if v.Binary == nil {
+			return nil, fmt.Errorf("%w: missing %s", ErrInvalidContentPart, v.Type)
+		}
+
+		return BinaryContent{MIMEType: v.Binary.MIMEType, Data: v.Binary.Data}, nil
+	case ContentPartTypeAudio:
+		if v.Audio == nil {
+			return nil, fmt.Errorf("%w: missing %s", ErrInvalidContentPart, v.Type)
+		}
+
+		return *v.Audio, nil
+	case ContentPartTypeFile:
+		if v.File == nil {
+			return nil, fmt.Errorf("%w: missing %s", ErrInvalidContentPart, v.Type)
+		}
+
+		return *v.File, nil
+	case ContentPartTypeAudioOutput:
+		if v.AudioOutput == nil {
+			return nil, fmt.Errorf("%w: missing %s", ErrInvalidContentPart, v.Type)
+		}
+
+		return *v.AudioOutput, nil
+	case ContentPartTypeToolCall:
+		if v.ToolCall == nil {
+			return nil, fmt.Errorf("%w: missing %s", ErrInvalidContentPart, v.Type)
+		}
+
+		return *v.ToolCall, nil
+	case ContentPartTypeToolCallResponse:
+		if v.ToolCallResponse == nil {
+			return nil, fmt.Errorf("%w: missing %s", ErrInvalidContentPart, v.Type)
+		}
+
+		return *v.ToolCallResponse, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnexpectedContentPartType, v.Type)
+	}
+}
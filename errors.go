@@ -52,4 +52,16 @@ var (
 	ErrChainInitialization = errors.New("error initializing chain")
 	// ErrAgentNoReturn is returned if the agent returns no actions and no finish.
 	ErrAgentNoReturn = errors.New("no actions or finish was returned by the agent")
+	// ErrUnexpectedContentPartType is returned when a content part is of an unexpected type.
+	ErrUnexpectedContentPartType = errors.New("unexpected content part type")
+	// ErrInvalidContentPart is returned when a content part could not be unmarshaled.
+	ErrInvalidContentPart = errors.New("invalid content part")
+	// ErrUnsupportedContentPart is returned when a provider can not handle a content part, such as audio.
+	ErrUnsupportedContentPart = errors.New("content part not supported by provider")
+	// ErrUnknownScheme is returned by Open when no provider has been registered for the scheme.
+	ErrUnknownScheme = errors.New("unknown provider scheme")
+	// ErrInvalidDSN is returned when a data source name could not be parsed.
+	ErrInvalidDSN = errors.New("invalid data source name")
+	// ErrContentFlagged is returned (wrapped in a ModerationError) when content is flagged by a Moderator.
+	ErrContentFlagged = errors.New("content flagged by moderation")
 )
@@ -0,0 +1,107 @@
+package llm
+
+import "context"
+
+// ImageGenerator is the interface for generating images from a prompt.
+type ImageGenerator interface {
+	// GenerateImages generates one or more images from the prompt.
+	GenerateImages(ctx context.Context, prompt string, options ...ImageOption) ([]GeneratedImage, error)
+}
+
+// ImageEditor is the interface for editing existing images.
+type ImageEditor interface {
+	// EditImage edits the image as described by the prompt, see WithImageMask.
+	EditImage(ctx context.Context, image []byte, prompt string, options ...ImageOption) ([]GeneratedImage, error)
+	// CreateImageVariations creates variations of the image.
+	CreateImageVariations(ctx context.Context, image []byte, options ...ImageOption) ([]GeneratedImage, error)
+}
+
+// Response formats for generated images.
+const (
+	ImageResponseFormatURL  = "url"
+	ImageResponseFormatData = "b64_json"
+)
+
+// GeneratedImage is an image returned by an ImageGenerator or ImageEditor.
+// Depending on the response format either the URL or the Data is set.
+type GeneratedImage struct {
+	// URL is where the image can be downloaded from.
+	URL string
+	// Data is the image data.
+	Data []byte
+	// MIMEType is the MIME type of the data, e.g. "image/png".
+	MIMEType string
+	// RevisedPrompt is the prompt that was actually used to generate the image, if revised by the model.
+	RevisedPrompt string
+}
+
+// ImageOption is a function that configures ImageOptions.
+type ImageOption func(*ImageOptions)
+
+// ImageOptions is a set of options for generating images.
+// Not all models support all options.
+type ImageOptions struct {
+	// Model is the model to use.
+	Model string `json:"model"`
+	// N is the number of images to generate.
+	N int `json:"n"`
+	// Size is the size of the images, e.g. "1024x1024".
+	Size string `json:"size"`
+	// Quality is the quality of the images, e.g. "standard", "hd" or "high".
+	Quality string `json:"quality"`
+	// Style is the style of the images, e.g. "vivid" or "natural".
+	Style string `json:"style"`
+	// ResponseFormat is the format of the returned images, ImageResponseFormatURL or ImageResponseFormatData.
+	ResponseFormat string `json:"response_format"`
+	// Mask is an image with transparent areas that indicate where an image should be edited.
+	Mask []byte `json:"-"`
+}
+
+// WithImageModel specifies which model to use when generating images.
+func WithImageModel(model string) ImageOption {
+	return func(o *ImageOptions) {
+		o.Model = model
+	}
+}
+
+// WithImageCount specifies the number of images to generate.
+func WithImageCount(n int) ImageOption {
+	return func(o *ImageOptions) {
+		o.N = n
+	}
+}
+
+// WithImageSize specifies the size of the generated images, e.g. "1024x1024".
+func WithImageSize(size string) ImageOption {
+	return func(o *ImageOptions) {
+		o.Size = size
+	}
+}
+
+// WithImageQuality specifies the quality of the generated images.
+func WithImageQuality(quality string) ImageOption {
+	return func(o *ImageOptions) {
+		o.Quality = quality
+	}
+}
+
+// WithImageStyle specifies the style of the generated images.
+func WithImageStyle(style string) ImageOption {
+	return func(o *ImageOptions) {
+		o.Style = style
+	}
+}
+
+// WithImageResponseFormat specifies if the images should be returned as URLs or data.
+func WithImageResponseFormat(format string) ImageOption {
+	return func(o *ImageOptions) {
+		o.ResponseFormat = format
+	}
+}
+
+// WithImageMask specifies the mask used by EditImage.
+func WithImageMask(mask []byte) ImageOption {
+	return func(o *ImageOptions) {
+		o.Mask = mask
+	}
+}
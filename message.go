@@ -1,5 +1,7 @@
 package llm
 
+import "encoding/json"
+
 // Message is the content of a message sent to a LLM. It has a role and a
 // sequence of parts. For example, it can represent one message in a chat
 // session sent by the user, in which case Role will be
@@ -8,6 +10,12 @@ package llm
 type Message struct {
 	Role  ChatMessageType
 	Parts []ContentPart
+
+	// Name is the name of the author of the message, if any.
+	// Providers that support it send the name along with the message.
+	Name string
+	// GenericRole is the role of a generic message, e.g. "critic".
+	GenericRole string
 }
 
 // TextPartsMessage is a helper function to create a Message with a role and a
@@ -24,3 +32,62 @@ func TextPartsMessage(role ChatMessageType, parts ...string) Message {
 
 	return result
 }
+
+// messageJSON is the JSON representation of a Message.
+type messageJSON struct {
+	Role        ChatMessageType   `json:"role"`
+	Parts       []json.RawMessage `json:"parts"`
+	Name        string            `json:"name,omitempty"`
+	GenericRole string            `json:"generic_role,omitempty"`
+}
+
+// MarshalJSON encodes the message with each part as a
+// discriminated union, see MarshalContentPart.
+func (m Message) MarshalJSON() ([]byte, error) {
+	v := messageJSON{
+		Role:        m.Role,
+		Parts:       make([]json.RawMessage, 0, len(m.Parts)),
+		Name:        m.Name,
+		GenericRole: m.GenericRole,
+	}
+
+	for _, part := range m.Parts {
+		data, err := MarshalContentPart(part)
+		if err != nil {
+			return nil, err
+		}
+
+		v.Parts = append(v.Parts, data)
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes a message encoded by MarshalJSON.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var v messageJSON
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	parts := make([]ContentPart, 0, len(v.Parts))
+
+	for _, raw := range v.Parts {
+		part, err := UnmarshalContentPart(raw)
+		if err != nil {
+			return err
+		}
+
+		parts = append(parts, part)
+	}
+
+	*m = Message{
+		Role:        v.Role,
+		Parts:       parts,
+		Name:        v.Name,
+		GenericRole: v.GenericRole,
+	}
+
+	return nil
+}
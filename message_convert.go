@@ -0,0 +1,138 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiPart is an interface for chat messages with content parts other than
+// text, such as images, e.g. HumanPartsChatMessage. ChatMessageToMessage uses
+// these parts instead of the MessageContent of the chat message.
+type MultiPart interface {
+	MessageParts() []ContentPart
+}
+
+// ChatMessagesToMessages converts the chat messages into messages that can be
+// sent to a Provider, see ChatMessageToMessage.
+func ChatMessagesToMessages(chatMessages []ChatMessage) []Message {
+	messages := make([]Message, 0, len(chatMessages))
+
+	for _, cm := range chatMessages {
+		messages = append(messages, ChatMessageToMessage(cm))
+	}
+
+	return messages
+}
+
+// ChatMessageToMessage converts a chat message into a message with the same role.
+// AI tool calls become ToolCall parts and tool messages become a ToolCallResponse part.
+// The role and name of generic messages are kept in GenericRole and Name.
+func ChatMessageToMessage(cm ChatMessage) Message {
+	m := Message{
+		Role:  cm.Type(),
+		Parts: []ContentPart{},
+	}
+
+	switch cm := cm.(type) {
+	case ToolChatMessage:
+		m.Parts = append(m.Parts, ToolCallResponse{
+			ToolCallID: cm.CallID,
+			Name:       cm.Name,
+			Content:    cm.Content,
+		})
+
+		return m
+	case GenericChatMessage:
+		m.Name, m.GenericRole = cm.Name, cm.Role
+		m.Parts = append(m.Parts, TextPart(cm.Content))
+	case MultiPart:
+		m.Parts = append(m.Parts, cm.MessageParts()...)
+	default:
+		if content := cm.MessageContent(); content != "" || cm.Type() != ChatMessageTypeAI {
+			m.Parts = append(m.Parts, TextPart(content))
+		}
+	}
+
+	if ai, ok := cm.(AIChatMessage); ok {
+		for _, tc := range ai.ToolCalls {
+			m.Parts = append(m.Parts, tc)
+		}
+
+		// use the legacy single function call if there are no tool calls
+		if len(ai.ToolCalls) == 0 && ai.FunctionCall != nil {
+			m.Parts = append(m.Parts, ToolCall{
+				Type:         "function",
+				FunctionCall: ai.FunctionCall,
+			})
+		}
+	}
+
+	return m
+}
+
+// MessageToChatMessage converts a message into a chat message of the same role.
+// The text parts are joined by newlines, and human messages with other parts,
+// such as images, become a HumanPartsChatMessage. An error is returned if the
+// message has parts that can not be represented by the chat message for the role.
+func MessageToChatMessage(m Message) (ChatMessage, error) {
+	var (
+		texts     []string
+		toolCalls []ToolCall
+		responses []ToolCallResponse
+		multiPart bool
+	)
+
+	for _, part := range m.Parts {
+		switch p := part.(type) {
+		case TextContent:
+			texts = append(texts, p.Text)
+		case ToolCall:
+			toolCalls = append(toolCalls, p)
+		case ToolCallResponse:
+			responses = append(responses, p)
+		default:
+			if m.Role != ChatMessageTypeHuman {
+				return nil, fmt.Errorf("%w: %T in %s message", ErrUnexpectedContentPartType, part, m.Role)
+			}
+
+			multiPart = true
+		}
+	}
+
+	content := strings.Join(texts, "\n")
+
+	if len(toolCalls) > 0 && m.Role != ChatMessageTypeAI {
+		return nil, fmt.Errorf("%w: ToolCall in %s message", ErrUnexpectedContentPartType, m.Role)
+	}
+
+	if len(responses) > 0 && (m.Role != ChatMessageTypeTool || len(responses) > 1 || len(texts) > 0) {
+		return nil, fmt.Errorf("%w: ToolCallResponse in %s message", ErrUnexpectedContentPartType, m.Role)
+	}
+
+	switch m.Role {
+	case ChatMessageTypeAI:
+		return AIChatMessage{Content: content, ToolCalls: toolCalls}, nil
+	case ChatMessageTypeHuman:
+		if multiPart {
+			return HumanPartsChatMessage{Parts: m.Parts}, nil
+		}
+
+		return HumanChatMessage{Content: content}, nil
+	case ChatMessageTypeSystem:
+		return SystemChatMessage{Content: content}, nil
+	case ChatMessageTypeGeneric:
+		return GenericChatMessage{Content: content, Role: m.GenericRole, Name: m.Name}, nil
+	case ChatMessageTypeTool:
+		if len(responses) == 0 {
+			return ToolChatMessage{Content: content}, nil
+		}
+
+		return ToolChatMessage{
+			CallID:  responses[0].ToolCallID,
+			Content: responses[0].Content,
+			Name:    responses[0].Name,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedChatMessageType, m.Role)
+	}
+}
@@ -0,0 +1,191 @@
+package llm_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+)
+
+func TestMessageJSON(t *testing.T) {
+	in := []llm.Message{
+		llm.TextParts(llm.ChatMessageTypeSystem, "You are a helpful assistant"),
+		{
+			Role: llm.ChatMessageTypeHuman,
+			Parts: []llm.ContentPart{
+				llm.TextPart("What is in this image?"),
+				llm.ImageURLWithDetailPart("https://example.com/image.png", "low"),
+				llm.BinaryPart("image/png", []byte{0x89, 0x50, 0x4e, 0x47}),
+				llm.AudioPart("wav", []byte("RIFF")),
+				llm.FilePart("report.pdf", "application/pdf", []byte("%PDF")),
+			},
+		},
+		{
+			Role: llm.ChatMessageTypeAI,
+			Parts: []llm.ContentPart{
+				llm.AudioOutputContent{ID: "audio_1", Transcript: "Let me check", ExpiresAt: 1700000000},
+				llm.ToolCall{
+					ID:   "call_1",
+					Type: "function",
+					FunctionCall: &llm.FunctionCall{
+						Name:      "describe",
+						Arguments: `{"detail":"high"}`,
+					},
+				},
+			},
+		},
+		{
+			Role: llm.ChatMessageTypeTool,
+			Parts: []llm.ContentPart{
+				llm.ToolCallResponse{ToolCallID: "call_1", Name: "describe", Content: "A cat"},
+			},
+		},
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out []llm.Message
+
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("round trip mismatch:\n got %#v\nwant %#v", out, in)
+	}
+
+	if err := json.Unmarshal([]byte(`{"role":"human","parts":[{"type":"unknown"}]}`), &llm.Message{}); err == nil {
+		t.Fatalf("expected error for unknown content part type")
+	}
+}
+
+func TestChatMessagesJSON(t *testing.T) {
+	in := []llm.ChatMessage{
+		llm.SystemChatMessage{Content: "system"},
+		llm.HumanChatMessage{Content: "human"},
+		llm.AIChatMessage{
+			Content:   "ai",
+			ToolCalls: []llm.ToolCall{{ID: "call_1", Type: "function", FunctionCall: &llm.FunctionCall{Name: "fn", Arguments: "{}"}}},
+		},
+		llm.ToolChatMessage{CallID: "call_1", Content: "tool"},
+		llm.GenericChatMessage{Content: "generic", Role: "narrator", Name: "Bob"},
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := llm.UnmarshalChatMessages(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("round trip mismatch:\n got %#v\nwant %#v", out, in)
+	}
+}
+
+func TestChatMessageToMessage(t *testing.T) {
+	toolCall := llm.ToolCall{
+		ID:           "call_1",
+		Type:         "function",
+		FunctionCall: &llm.FunctionCall{Name: "fn", Arguments: "{}"},
+	}
+
+	for _, tt := range []struct {
+		cm   llm.ChatMessage
+		want llm.Message
+	}{
+		{
+			llm.HumanChatMessage{Content: "hello"},
+			llm.TextParts(llm.ChatMessageTypeHuman, "hello"),
+		},
+		{
+			llm.AIChatMessage{ToolCalls: []llm.ToolCall{toolCall}},
+			llm.Message{Role: llm.ChatMessageTypeAI, Parts: []llm.ContentPart{toolCall}},
+		},
+		{
+			llm.ToolChatMessage{CallID: "call_1", Content: "result"},
+			llm.Message{Role: llm.ChatMessageTypeTool, Parts: []llm.ContentPart{
+				llm.ToolCallResponse{ToolCallID: "call_1", Content: "result"},
+			}},
+		},
+		{
+			llm.ToolChatMessage{CallID: "call_1", Content: "result", Name: "fn"},
+			llm.Message{Role: llm.ChatMessageTypeTool, Parts: []llm.ContentPart{
+				llm.ToolCallResponse{ToolCallID: "call_1", Name: "fn", Content: "result"},
+			}},
+		},
+		{
+			llm.GenericChatMessage{Content: "looks good", Role: "critic", Name: "alice"},
+			llm.Message{
+				Role:        llm.ChatMessageTypeGeneric,
+				Parts:       []llm.ContentPart{llm.TextPart("looks good")},
+				Name:        "alice",
+				GenericRole: "critic",
+			},
+		},
+		{
+			llm.HumanPartsChatMessage{Parts: []llm.ContentPart{
+				llm.TextPart("what is this?"),
+				llm.ImageURLPart("https://example.com/cat.png"),
+			}},
+			llm.Message{Role: llm.ChatMessageTypeHuman, Parts: []llm.ContentPart{
+				llm.TextPart("what is this?"),
+				llm.ImageURLPart("https://example.com/cat.png"),
+			}},
+		},
+	} {
+		m := llm.ChatMessageToMessage(tt.cm)
+
+		if !reflect.DeepEqual(m, tt.want) {
+			t.Fatalf("ChatMessageToMessage(%#v) = %#v, want %#v", tt.cm, m, tt.want)
+		}
+
+		cm, err := llm.MessageToChatMessage(m)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(cm, tt.cm) {
+			t.Fatalf("MessageToChatMessage(%#v) = %#v, want %#v", m, cm, tt.cm)
+		}
+	}
+
+	if _, err := llm.MessageToChatMessage(llm.Message{
+		Role:  llm.ChatMessageTypeSystem,
+		Parts: []llm.ContentPart{toolCall},
+	}); err == nil {
+		t.Fatalf("expected error for ToolCall in system message")
+	}
+}
+
+func TestHumanPartsChatMessageJSON(t *testing.T) {
+	in := llm.HumanPartsChatMessage{Parts: []llm.ContentPart{
+		llm.TextPart("what is this?"),
+		llm.ImageURLPart("https://example.com/cat.png"),
+	}}
+
+	if got, want := in.MessageContent(), "what is this?"; got != want {
+		t.Fatalf("MessageContent() = %q, want %q", got, want)
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := llm.UnmarshalChatMessage(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("round trip mismatch:\n got %#v\nwant %#v", out, in)
+	}
+}
@@ -11,6 +11,7 @@ var (
 	_ llm.ChainHooker = Chain{}
 )
 
+// Chain is a mock chain, where MemoryFunc and ChainHooksFunc are optional.
 type Chain struct {
 	CallFunc       func(context.Context, map[string]any, ...llm.ChainOption) (map[string]any, error)
 	MemoryFunc     func() llm.Memory
@@ -19,12 +20,32 @@ type Chain struct {
 	ChainHooksFunc func() llm.ChainHooks
 }
 
+// TextChain returns a chain with the input keys, that returns the result of fn in the output key.
+func TextChain(inputKeys []string, outputKey string, fn func(values map[string]any) (string, error)) Chain {
+	return Chain{
+		CallFunc: func(_ context.Context, values map[string]any, _ ...llm.ChainOption) (map[string]any, error) {
+			text, err := fn(values)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]any{outputKey: text}, nil
+		},
+		InputKeysFunc:  func() []string { return inputKeys },
+		OutputKeysFunc: func() []string { return []string{outputKey} },
+	}
+}
+
 func (c Chain) Call(ctx context.Context, values map[string]any, options ...llm.ChainOption) (map[string]any, error) {
 	return c.CallFunc(ctx, values, options...)
 }
 
 func (c Chain) Memory() llm.Memory {
-	return c.MemoryFunc()
+	if c.MemoryFunc != nil {
+		return c.MemoryFunc()
+	}
+
+	return llm.EmptyMemory{}
 }
 
 func (c Chain) InputKeys() []string {
@@ -36,5 +57,9 @@ func (c Chain) OutputKeys() []string {
 }
 
 func (c Chain) ChainHooks() llm.ChainHooks {
-	return c.ChainHooksFunc()
+	if c.ChainHooksFunc != nil {
+		return c.ChainHooksFunc()
+	}
+
+	return nil
 }
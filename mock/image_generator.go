@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/peterhellberg/llm"
+)
+
+var (
+	_ llm.ImageGenerator = ImageGenerator{}
+	_ llm.ImageEditor    = ImageGenerator{}
+)
+
+type ImageGenerator struct {
+	GenerateImagesFunc        func(ctx context.Context, prompt string, options ...llm.ImageOption) ([]llm.GeneratedImage, error)
+	EditImageFunc             func(ctx context.Context, image []byte, prompt string, options ...llm.ImageOption) ([]llm.GeneratedImage, error)
+	CreateImageVariationsFunc func(ctx context.Context, image []byte, options ...llm.ImageOption) ([]llm.GeneratedImage, error)
+}
+
+func (m ImageGenerator) GenerateImages(ctx context.Context, prompt string, options ...llm.ImageOption) ([]llm.GeneratedImage, error) {
+	return m.GenerateImagesFunc(ctx, prompt, options...)
+}
+
+func (m ImageGenerator) EditImage(ctx context.Context, image []byte, prompt string, options ...llm.ImageOption) ([]llm.GeneratedImage, error) {
+	return m.EditImageFunc(ctx, image, prompt, options...)
+}
+
+func (m ImageGenerator) CreateImageVariations(ctx context.Context, image []byte, options ...llm.ImageOption) ([]llm.GeneratedImage, error) {
+	return m.CreateImageVariationsFunc(ctx, image, options...)
+}
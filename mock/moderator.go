@@ -0,0 +1,17 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Moderator = Moderator{}
+
+type Moderator struct {
+	ModerateFunc func(ctx context.Context, inputs []string) ([]llm.ModerationResult, error)
+}
+
+func (m Moderator) Moderate(ctx context.Context, inputs []string) ([]llm.ModerationResult, error) {
+	return m.ModerateFunc(ctx, inputs)
+}
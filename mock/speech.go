@@ -0,0 +1,29 @@
+package mock
+
+import (
+	"context"
+	"io"
+
+	"github.com/peterhellberg/llm"
+)
+
+var (
+	_ llm.Transcriber       = Transcriber{}
+	_ llm.SpeechSynthesizer = SpeechSynthesizer{}
+)
+
+type Transcriber struct {
+	TranscribeFunc func(ctx context.Context, r io.Reader, options ...llm.TranscriptionOption) (*llm.Transcription, error)
+}
+
+func (m Transcriber) Transcribe(ctx context.Context, r io.Reader, options ...llm.TranscriptionOption) (*llm.Transcription, error) {
+	return m.TranscribeFunc(ctx, r, options...)
+}
+
+type SpeechSynthesizer struct {
+	SynthesizeSpeechFunc func(ctx context.Context, text string, options ...llm.SpeechOption) (io.ReadCloser, error)
+}
+
+func (m SpeechSynthesizer) SynthesizeSpeech(ctx context.Context, text string, options ...llm.SpeechOption) (io.ReadCloser, error) {
+	return m.SynthesizeSpeechFunc(ctx, text, options...)
+}
@@ -0,0 +1,47 @@
+package llm
+
+import "context"
+
+// ModelLister is an optional interface implemented by providers that can
+// list the models offered by their backend.
+type ModelLister interface {
+	// ListModels returns information about the available models.
+	ListModels(ctx context.Context) ([]ModelInfo, error)
+}
+
+// ModelType is the type of a model.
+type ModelType string
+
+const (
+	// ModelTypeUnknown is used when the type of the model could not be determined.
+	ModelTypeUnknown ModelType = ""
+	// ModelTypeChat is a model that generates content from messages.
+	ModelTypeChat ModelType = "chat"
+	// ModelTypeEmbedding is a model that creates vector embeddings.
+	ModelTypeEmbedding ModelType = "embedding"
+	// ModelTypeModeration is a model that classifies content.
+	ModelTypeModeration ModelType = "moderation"
+	// ModelTypeImage is a model that generates images.
+	ModelTypeImage ModelType = "image"
+	// ModelTypeAudio is a model that transcribes or synthesizes speech.
+	ModelTypeAudio ModelType = "audio"
+)
+
+// ModelInfo is information about a model offered by a provider.
+// Fields the backend does not report are left as their zero values.
+type ModelInfo struct {
+	// ID is the identifier used to select the model.
+	ID string
+
+	// Type is the type of the model.
+	Type ModelType
+
+	// ContextWindow is the maximum number of tokens in the context window.
+	ContextWindow int
+
+	// Modalities is the list of input modalities, e.g. "text" and "image".
+	Modalities []string
+
+	// Metadata is any additional information reported by the backend.
+	Metadata map[string]any
+}
@@ -0,0 +1,65 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Moderator is the interface for classifying content as potentially harmful.
+type Moderator interface {
+	// Moderate returns one ModerationResult for each of the inputs.
+	Moderate(ctx context.Context, inputs []string) ([]ModerationResult, error)
+}
+
+// ModerationResult is the moderation verdict for a single input.
+type ModerationResult struct {
+	// Flagged is true if the input was flagged in any of the categories.
+	Flagged bool
+
+	// Categories maps each category to whether it was flagged or not.
+	Categories map[string]bool
+
+	// CategoryScores maps each category to the score given by the model.
+	CategoryScores map[string]float64
+}
+
+// FlaggedCategories returns the sorted names of the flagged categories.
+func (r ModerationResult) FlaggedCategories() []string {
+	categories := []string{}
+
+	for category, flagged := range r.Categories {
+		if flagged {
+			categories = append(categories, category)
+		}
+	}
+
+	sort.Strings(categories)
+
+	return categories
+}
+
+// ModerationError is the error returned when content is flagged by a Moderator.
+type ModerationError struct {
+	// Input is the content that was flagged.
+	Input string
+
+	// Result is the moderation result for the input.
+	Result ModerationResult
+}
+
+func (e ModerationError) Error() string {
+	categories := e.Result.FlaggedCategories()
+
+	if len(categories) == 0 {
+		return ErrContentFlagged.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", ErrContentFlagged, strings.Join(categories, ", "))
+}
+
+// Unwrap makes it possible to check for ErrContentFlagged using errors.Is.
+func (e ModerationError) Unwrap() error {
+	return ErrContentFlagged
+}
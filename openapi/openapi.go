@@ -0,0 +1,254 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+)
+
+const (
+	defaultMaxResponseBytes = 64 << 10
+	maxRedirects            = 10
+)
+
+var (
+	// ErrInvalidSpec is returned when the OpenAPI document can not be used.
+	ErrInvalidSpec = errors.New("invalid OpenAPI spec")
+
+	// ErrBaseURLNotAllowed is returned when the base URL is not in the allowlist.
+	ErrBaseURLNotAllowed = errors.New("base URL is not allowed")
+
+	// ErrInvalidArguments is returned when the arguments of a tool call are invalid.
+	ErrInvalidArguments = errors.New("invalid tool arguments")
+)
+
+// API creates tools for the operations in an OpenAPI document, that send
+// requests to the BaseURL. The BaseURL must be in the AllowedBaseURLs.
+type API struct {
+	// Spec is the OpenAPI document.
+	Spec *Spec
+
+	// BaseURL is the URL the paths are relative to, by default the URL of the first server.
+	BaseURL string
+
+	// AllowedBaseURLs are the URLs that the BaseURL must be equal to, or below.
+	AllowedBaseURLs []string
+
+	// Client is used to send the requests, by default a client that only
+	// follows redirects to URLs in the AllowedBaseURLs.
+	Client llm.HTTPDoer
+
+	// Headers are added to every request, e.g. for authentication.
+	Headers http.Header
+
+	// MaxResponseBytes is the maximum number of bytes of the response
+	// body returned as the observation, by default 64 KiB.
+	MaxResponseBytes int64
+
+	tools []Tool
+}
+
+// New creates a new API for the OpenAPI document. It returns an error if the base URL is not
+// allowed, or if any of the operations can not be turned into a tool.
+func New(spec *Spec, options ...func(*API)) (*API, error) {
+	a := &API{
+		Spec:             spec,
+		Headers:          http.Header{},
+		MaxResponseBytes: defaultMaxResponseBytes,
+	}
+
+	if len(spec.Servers) > 0 {
+		a.BaseURL = spec.Servers[0].URL
+	}
+
+	for _, opt := range options {
+		opt(a)
+	}
+
+	if a.Client == nil {
+		a.Client = &http.Client{CheckRedirect: a.checkRedirect}
+	}
+
+	if err := a.checkBaseURL(); err != nil {
+		return nil, err
+	}
+
+	tools, err := a.createTools()
+	if err != nil {
+		return nil, err
+	}
+
+	a.tools = tools
+
+	return a, nil
+}
+
+// WithBaseURL sets the base URL of the API.
+func WithBaseURL(baseURL string) func(*API) {
+	return func(a *API) {
+		a.BaseURL = baseURL
+	}
+}
+
+// WithAllowedBaseURLs sets the URLs the base URL must be equal to, or below.
+func WithAllowedBaseURLs(urls ...string) func(*API) {
+	return func(a *API) {
+		a.AllowedBaseURLs = urls
+	}
+}
+
+// WithClient sets the client used to send the requests. The allowlist is only checked
+// before a request is sent, so the client should not follow redirects to other URLs.
+func WithClient(client llm.HTTPDoer) func(*API) {
+	return func(a *API) {
+		a.Client = client
+	}
+}
+
+// WithHeader adds a header to every request, e.g. "Authorization".
+func WithHeader(key, value string) func(*API) {
+	return func(a *API) {
+		a.Headers.Add(key, value)
+	}
+}
+
+// Tools returns a tool for each operation, sorted by name.
+func (a *API) Tools() []Tool {
+	return slices.Clone(a.tools)
+}
+
+// AgentTools returns the tools as agent tools.
+func (a *API) AgentTools() []llm.AgentTool {
+	tools := make([]llm.AgentTool, len(a.tools))
+
+	for i, t := range a.tools {
+		tools[i] = t
+	}
+
+	return tools
+}
+
+// Definitions returns the function definitions of the tools, to be used with llm.WithTools.
+func (a *API) Definitions() []llm.Tool {
+	tools := make([]llm.Tool, len(a.tools))
+
+	for i, t := range a.tools {
+		tools[i] = t.Definition()
+	}
+
+	return tools
+}
+
+// Tool returns the tool with the name.
+func (a *API) Tool(name string) (Tool, bool) {
+	for _, t := range a.tools {
+		if t.name == name {
+			return t, true
+		}
+	}
+
+	return Tool{}, false
+}
+
+func (a *API) createTools() ([]Tool, error) {
+	var tools []Tool
+
+	for path, item := range a.Spec.Paths {
+		for method, op := range item.operations() {
+			t, err := newTool(a, method, path, item.Parameters, op)
+			if err != nil {
+				return nil, err
+			}
+
+			tools = append(tools, t)
+		}
+	}
+
+	slices.SortFunc(tools, func(x, y Tool) int {
+		return strings.Compare(x.name, y.name)
+	})
+
+	for i := 1; i < len(tools); i++ {
+		if tools[i].name == tools[i-1].name {
+			return nil, fmt.Errorf("%w: duplicate tool name %q", ErrInvalidSpec, tools[i].name)
+		}
+	}
+
+	return tools, nil
+}
+
+func (a *API) checkBaseURL() error {
+	if !a.allowed(a.BaseURL) {
+		return fmt.Errorf("%w: %q", ErrBaseURLNotAllowed, a.BaseURL)
+	}
+
+	return nil
+}
+
+// checkRedirect stops redirects to URLs that are not allowed, since they would
+// be sent the headers of the API, and redirects after 10 requests like Go does.
+func (a *API) checkRedirect(req *http.Request, via []*http.Request) error {
+	if !a.allowed(req.URL.String()) {
+		return fmt.Errorf("%w: redirect to %q", ErrBaseURLNotAllowed, req.URL)
+	}
+
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	return nil
+}
+
+// allowed returns true if the URL has the same scheme and host as one of the
+// allowed base URLs, and a path equal to or below the path of that URL.
+func (a *API) allowed(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User != nil {
+		return false
+	}
+
+	for _, rawAllowed := range a.AllowedBaseURLs {
+		allowed, err := url.Parse(rawAllowed)
+		if err != nil {
+			continue
+		}
+
+		if !strings.EqualFold(u.Scheme, allowed.Scheme) || !strings.EqualFold(u.Host, allowed.Host) {
+			continue
+		}
+
+		prefix := strings.TrimSuffix(allowed.EscapedPath(), "/")
+		path := u.EscapedPath()
+
+		if path == prefix || strings.HasPrefix(path, prefix+"/") || (prefix == "" && path == "") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p PathItem) operations() map[string]*Operation {
+	operations := map[string]*Operation{}
+
+	for method, op := range map[string]*Operation{
+		http.MethodGet:     p.Get,
+		http.MethodPut:     p.Put,
+		http.MethodPost:    p.Post,
+		http.MethodDelete:  p.Delete,
+		http.MethodOptions: p.Options,
+		http.MethodHead:    p.Head,
+		http.MethodPatch:   p.Patch,
+	} {
+		if op != nil {
+			operations[method] = op
+		}
+	}
+
+	return operations
+}
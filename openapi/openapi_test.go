@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+)
+
+const petstore = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Petstore", "version": "1.0.0"},
+  "servers": [{"url": "https://pets.example.com/v1"}],
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "summary": "List all pets",
+        "parameters": [{"$ref": "#/components/parameters/limit"}]
+      },
+      "post": {
+        "operationId": "createPet",
+        "summary": "Create a pet",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+        }
+      }
+    },
+    "/pets/{petId}": {
+      "parameters": [{"name": "petId", "in": "path", "required": true, "schema": {"type": "string"}}],
+      "get": {"summary": "Info for a specific pet"}
+    }
+  },
+  "components": {
+    "parameters": {
+      "limit": {"name": "limit", "in": "query", "description": "How many items to return", "schema": {"type": "integer"}}
+    },
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "required": ["name"],
+        "properties": {
+          "name": {"type": "string"},
+          "parent": {"$ref": "#/components/schemas/Pet"}
+        }
+      }
+    }
+  }
+}`
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func response(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func newAPI(t *testing.T, doer llm.HTTPDoer, options ...func(*API)) *API {
+	t.Helper()
+
+	spec, err := Parse([]byte(petstore))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	options = append([]func(*API){
+		WithAllowedBaseURLs("https://pets.example.com/v1"),
+		WithClient(doer),
+		WithHeader("Authorization", "Bearer secret"),
+	}, options...)
+
+	api, err := New(spec, options...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return api
+}
+
+func TestAPITools(t *testing.T) {
+	api := newAPI(t, nil)
+
+	var names []string
+
+	for _, tool := range api.AgentTools() {
+		names = append(names, tool.Name())
+	}
+
+	if got, want := strings.Join(names, ","), "createPet,get_pets_petId,listPets"; got != want {
+		t.Fatalf("names = %q, want %q", got, want)
+	}
+
+	tool, _ := api.Tool("createPet")
+
+	body := tool.Parameters()["properties"].(map[string]any)["body"].(map[string]any)
+	parent := body["properties"].(map[string]any)["parent"].(map[string]any)
+
+	if got, want := parent["type"], "object"; got != want {
+		t.Fatalf("parent type = %v, want %v", got, want)
+	}
+
+	if got, want := api.Definitions()[2].Function.Description, "List all pets"; got != want {
+		t.Fatalf("description = %q, want %q", got, want)
+	}
+}
+
+func TestToolCall(t *testing.T) {
+	var got *http.Request
+
+	api := newAPI(t, doerFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+
+		if req.URL.Path == "/v1/pets/missing" {
+			return response(http.StatusNotFound, `{"error":"not found"}`), nil
+		}
+
+		return response(http.StatusOK, `{"id":1,"name":"Rex"}`), nil
+	}))
+
+	tool, _ := api.Tool("get_pets_petId")
+
+	out, err := tool.Call(context.Background(), `{"petId": "1 2", "Authorization": "nope"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := `{"id":1,"name":"Rex"}`; out != want {
+		t.Fatalf("out = %q, want %q", out, want)
+	}
+
+	if got, want := got.URL.String(), "https://pets.example.com/v1/pets/1%202"; got != want {
+		t.Fatalf("url = %q, want %q", got, want)
+	}
+
+	if got, want := got.Header.Get("Authorization"), "Bearer secret"; got != want {
+		t.Fatalf("Authorization = %q, want %q", got, want)
+	}
+
+	if out, _ = tool.Call(context.Background(), `{"petId": "missing"}`); !strings.HasPrefix(out, "Not Found\n") {
+		t.Fatalf("out = %q, want it to start with the status", out)
+	}
+
+	if _, err := tool.Call(context.Background(), `{"petId": ".."}`); !errors.Is(err, ErrInvalidArguments) {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidArguments)
+	}
+
+	if _, err := tool.Call(context.Background(), `{}`); !errors.Is(err, ErrInvalidArguments) {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidArguments)
+	}
+}
+
+func TestToolCallRedirect(t *testing.T) {
+	var leaked bool
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = true
+	}))
+	defer other.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/pets/old":
+			http.Redirect(w, r, "/v1/pets/1", http.StatusFound)
+		case "/v1/pets/away":
+			http.Redirect(w, r, other.URL+"/steal", http.StatusFound)
+		default:
+			w.Write([]byte(`{"id":1}`))
+		}
+	}))
+	defer srv.Close()
+
+	api := newAPI(t, nil, WithBaseURL(srv.URL+"/v1"), WithAllowedBaseURLs(srv.URL+"/v1"))
+
+	tool, _ := api.Tool("get_pets_petId")
+
+	out, err := tool.Call(context.Background(), `{"petId": "old"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := `{"id":1}`; out != want {
+		t.Fatalf("out = %q, want %q", out, want)
+	}
+
+	if _, err := tool.Call(context.Background(), `{"petId": "away"}`); !errors.Is(err, ErrBaseURLNotAllowed) {
+		t.Fatalf("err = %v, want %v", err, ErrBaseURLNotAllowed)
+	}
+
+	if leaked {
+		t.Fatalf("request was redirected to a URL that is not allowed")
+	}
+}
+
+func TestToolCallBody(t *testing.T) {
+	var body, contentType, query string
+
+	api := newAPI(t, doerFunc(func(req *http.Request) (*http.Response, error) {
+		query, contentType = req.URL.RawQuery, req.Header.Get("Content-Type")
+
+		if req.Body != nil {
+			data, _ := io.ReadAll(req.Body)
+			body = string(data)
+		}
+
+		return response(http.StatusCreated, ""), nil
+	}))
+
+	create, _ := api.Tool("createPet")
+
+	if _, err := create.Call(context.Background(), `{"body": {"name": "Rex"}}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := `{"name":"Rex"}`; body != want {
+		t.Fatalf("body = %q, want %q", body, want)
+	}
+
+	if want := "application/json"; contentType != want {
+		t.Fatalf("Content-Type = %q, want %q", contentType, want)
+	}
+
+	list, _ := api.Tool("listPets")
+
+	if _, err := list.Call(context.Background(), `{"limit": 10}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "limit=10"; query != want {
+		t.Fatalf("query = %q, want %q", query, want)
+	}
+}
+
+func TestNewBaseURLNotAllowed(t *testing.T) {
+	spec, err := Parse([]byte(petstore))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, allowed := range []string{"", "https://pets.example.com/v2", "http://pets.example.com/v1", "https://pets.example.com.evil/v1"} {
+		if _, err := New(spec, WithAllowedBaseURLs(allowed)); !errors.Is(err, ErrBaseURLNotAllowed) {
+			t.Fatalf("allowed %q: err = %v, want %v", allowed, err, ErrBaseURLNotAllowed)
+		}
+	}
+
+	if _, err := New(spec, WithAllowedBaseURLs("https://pets.example.com")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	if _, err := Parse([]byte(`{"swagger": "2.0"}`)); !errors.Is(err, ErrInvalidSpec) {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidSpec)
+	}
+}
@@ -0,0 +1,199 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Spec is the subset of an OpenAPI 3 document used to create tools.
+type Spec struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info is the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// Server is a server of the API.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+// PathItem is the operations available on a path.
+type PathItem struct {
+	Parameters []Parameter `json:"parameters"`
+	Get        *Operation  `json:"get"`
+	Put        *Operation  `json:"put"`
+	Post       *Operation  `json:"post"`
+	Delete     *Operation  `json:"delete"`
+	Options    *Operation  `json:"options"`
+	Head       *Operation  `json:"head"`
+	Patch      *Operation  `json:"patch"`
+}
+
+// Operation is a single API operation on a path.
+type Operation struct {
+	OperationID string       `json:"operationId"`
+	Summary     string       `json:"summary"`
+	Description string       `json:"description"`
+	Parameters  []Parameter  `json:"parameters"`
+	RequestBody *RequestBody `json:"requestBody"`
+	Deprecated  bool         `json:"deprecated"`
+}
+
+// Parameter is a path, query, header or cookie parameter of an operation.
+type Parameter struct {
+	Ref         string         `json:"$ref"`
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description"`
+	Required    bool           `json:"required"`
+	Schema      map[string]any `json:"schema"`
+}
+
+// RequestBody is the request body of an operation.
+type RequestBody struct {
+	Ref         string               `json:"$ref"`
+	Description string               `json:"description"`
+	Required    bool                 `json:"required"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// MediaType is the schema of a request body for a media type.
+type MediaType struct {
+	Schema map[string]any `json:"schema"`
+}
+
+// Components are the reusable parts of the document.
+type Components struct {
+	Schemas       map[string]map[string]any `json:"schemas"`
+	Parameters    map[string]Parameter      `json:"parameters"`
+	RequestBodies map[string]RequestBody    `json:"requestBodies"`
+}
+
+// Parse parses an OpenAPI 3 document in JSON.
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%w: unsupported version %q", ErrInvalidSpec, spec.OpenAPI)
+	}
+
+	return &spec, nil
+}
+
+func (s *Spec) parameter(p Parameter) (Parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+
+	name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/")
+	if !ok {
+		return p, fmt.Errorf("%w: unsupported reference %q", ErrInvalidSpec, p.Ref)
+	}
+
+	resolved, ok := s.Components.Parameters[name]
+	if !ok {
+		return p, fmt.Errorf("%w: unknown reference %q", ErrInvalidSpec, p.Ref)
+	}
+
+	return resolved, nil
+}
+
+func (s *Spec) requestBody(b *RequestBody) (*RequestBody, error) {
+	if b == nil || b.Ref == "" {
+		return b, nil
+	}
+
+	name, ok := strings.CutPrefix(b.Ref, "#/components/requestBodies/")
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported reference %q", ErrInvalidSpec, b.Ref)
+	}
+
+	resolved, ok := s.Components.RequestBodies[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown reference %q", ErrInvalidSpec, b.Ref)
+	}
+
+	return &resolved, nil
+}
+
+// schema returns a copy of the schema with all references to component schemas resolved.
+// Recursive references are replaced by an object schema.
+func (s *Spec) schema(schema map[string]any) (map[string]any, error) {
+	resolved, err := s.resolve(schema, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	m, _ := resolved.(map[string]any)
+
+	return m, nil
+}
+
+func (s *Spec) resolve(v any, resolving map[string]bool) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			if resolving[ref] {
+				return map[string]any{"type": "object"}, nil
+			}
+
+			name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+			if !ok {
+				return nil, fmt.Errorf("%w: unsupported reference %q", ErrInvalidSpec, ref)
+			}
+
+			schema, ok := s.Components.Schemas[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown reference %q", ErrInvalidSpec, ref)
+			}
+
+			resolving[ref] = true
+			defer delete(resolving, ref)
+
+			return s.resolve(schema, resolving)
+		}
+
+		m := make(map[string]any, len(v))
+
+		for key, value := range v {
+			resolved, err := s.resolve(value, resolving)
+			if err != nil {
+				return nil, err
+			}
+
+			m[key] = resolved
+		}
+
+		return m, nil
+	case []any:
+		a := make([]any, len(v))
+
+		for i, value := range v {
+			resolved, err := s.resolve(value, resolving)
+			if err != nil {
+				return nil, err
+			}
+
+			a[i] = resolved
+		}
+
+		return a, nil
+	default:
+		return v, nil
+	}
+}
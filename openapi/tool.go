@@ -0,0 +1,384 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.AgentTool = Tool{}
+
+const (
+	bodyArgument  = "body"
+	maxNameLength = 64
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// Tool calls a single operation of the API. The input of the tool is a JSON object with
+// a property for each parameter of the operation, and the request body in "body".
+type Tool struct {
+	api *API
+
+	name        string
+	description string
+	method      string
+	path        string
+	parameters  []Parameter
+	contentType string
+	schema      map[string]any
+}
+
+func newTool(a *API, method, path string, pathParameters []Parameter, op *Operation) (Tool, error) {
+	t := Tool{
+		api:         a,
+		name:        toolName(method, path, op.OperationID),
+		description: toolDescription(method, path, op),
+		method:      method,
+		path:        path,
+	}
+
+	properties := map[string]any{}
+	required := []string{}
+
+	parameters, err := t.resolveParameters(pathParameters, op.Parameters)
+	if err != nil {
+		return t, err
+	}
+
+	for _, p := range parameters {
+		schema, err := a.Spec.schema(p.Schema)
+		if err != nil {
+			return t, err
+		}
+
+		if schema == nil {
+			schema = map[string]any{"type": "string"}
+		}
+
+		if p.Description != "" {
+			schema["description"] = p.Description
+		}
+
+		properties[p.Name] = schema
+
+		if p.Required || p.In == "path" {
+			required = append(required, p.Name)
+		}
+	}
+
+	t.parameters = parameters
+
+	body, err := a.Spec.requestBody(op.RequestBody)
+	if err != nil {
+		return t, err
+	}
+
+	if body != nil {
+		contentType, media := requestContent(body.Content)
+
+		schema := map[string]any{"type": "string"}
+
+		if isJSON(contentType) {
+			if schema, err = a.Spec.schema(media.Schema); err != nil {
+				return t, err
+			}
+
+			if schema == nil {
+				schema = map[string]any{"type": "object"}
+			}
+		}
+
+		if body.Description != "" {
+			schema["description"] = body.Description
+		}
+
+		properties[bodyArgument] = schema
+		t.contentType = contentType
+
+		if body.Required {
+			required = append(required, bodyArgument)
+		}
+	}
+
+	t.schema = map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+
+	return t, nil
+}
+
+// Name returns the name of the tool, the operation ID if set.
+func (t Tool) Name() string {
+	return t.name
+}
+
+// Description returns the summary and description of the operation.
+func (t Tool) Description() string {
+	return t.description
+}
+
+// Parameters returns the JSON schema of the input of the tool.
+func (t Tool) Parameters() map[string]any {
+	return t.schema
+}
+
+// Definition returns the function definition of the tool.
+func (t Tool) Definition() llm.Tool {
+	return llm.Tool{
+		Type: "function",
+		Function: &llm.FunctionDefinition{
+			Name:        t.name,
+			Description: t.description,
+			Parameters:  t.schema,
+		},
+	}
+}
+
+// Call sends the request for the operation with the arguments in the JSON input, and returns the
+// response body. The body of responses with an error status is prefixed by the status, so that
+// the model can act on the error.
+func (t Tool) Call(ctx context.Context, input string) (string, error) {
+	args := map[string]any{}
+
+	if strings.TrimSpace(input) != "" {
+		if err := json.Unmarshal([]byte(input), &args); err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+		}
+	}
+
+	req, err := t.request(ctx, args)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := t.api.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.api.MaxResponseBytes))
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.Status + "\n" + string(body), nil
+	}
+
+	return string(body), nil
+}
+
+func (t Tool) request(ctx context.Context, args map[string]any) (*http.Request, error) {
+	var (
+		path    = t.path
+		query   = url.Values{}
+		headers = http.Header{}
+		cookies []*http.Cookie
+	)
+
+	for _, p := range t.parameters {
+		v, ok := args[p.Name]
+		if !ok || v == nil {
+			if p.Required || p.In == "path" {
+				return nil, fmt.Errorf("%w: missing %s parameter %q", ErrInvalidArguments, p.In, p.Name)
+			}
+
+			continue
+		}
+
+		switch p.In {
+		case "path":
+			value := formatArgument(v)
+
+			if value == "" || value == "." || value == ".." {
+				return nil, fmt.Errorf("%w: invalid path parameter %q", ErrInvalidArguments, p.Name)
+			}
+
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", url.PathEscape(value))
+		case "query":
+			if values, ok := v.([]any); ok {
+				for _, value := range values {
+					query.Add(p.Name, formatArgument(value))
+				}
+			} else {
+				query.Add(p.Name, formatArgument(v))
+			}
+		case "header":
+			headers.Set(p.Name, formatArgument(v))
+		case "cookie":
+			cookies = append(cookies, &http.Cookie{Name: p.Name, Value: formatArgument(v)})
+		}
+	}
+
+	rawURL := strings.TrimSuffix(t.api.BaseURL, "/") + path
+
+	if len(query) > 0 {
+		rawURL += "?" + query.Encode()
+	}
+
+	if !t.api.allowed(rawURL) {
+		return nil, fmt.Errorf("%w: %q", ErrBaseURLNotAllowed, rawURL)
+	}
+
+	body, err := t.body(args)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, t.method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = headers
+
+	for key, values := range t.api.Headers {
+		req.Header[http.CanonicalHeaderKey(key)] = slices.Clone(values)
+	}
+
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", t.contentType)
+	}
+
+	return req, nil
+}
+
+func (t Tool) body(args map[string]any) (io.Reader, error) {
+	v, ok := args[bodyArgument]
+	if !ok || t.contentType == "" {
+		return nil, nil
+	}
+
+	if !isJSON(t.contentType) {
+		return strings.NewReader(formatArgument(v)), nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+
+	return bytes.NewReader(data), nil
+}
+
+// resolveParameters resolves the references of the parameters, where the
+// parameters of the operation override the parameters of the path.
+func (t Tool) resolveParameters(pathParameters, operationParameters []Parameter) ([]Parameter, error) {
+	var parameters []Parameter
+
+	for _, p := range append(append([]Parameter{}, pathParameters...), operationParameters...) {
+		resolved, err := t.api.Spec.parameter(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if resolved.Name == "" {
+			return nil, fmt.Errorf("%w: parameter without name in %s %s", ErrInvalidSpec, t.method, t.path)
+		}
+
+		replaced := false
+
+		for i, existing := range parameters {
+			if existing.Name == resolved.Name && existing.In == resolved.In {
+				parameters[i], replaced = resolved, true
+			}
+		}
+
+		if !replaced {
+			parameters = append(parameters, resolved)
+		}
+	}
+
+	return parameters, nil
+}
+
+// toolName returns the operation ID, or the method and path, as a valid function name.
+func toolName(method, path, operationID string) string {
+	name := operationID
+
+	if name == "" {
+		name = strings.ToLower(method) + path
+	}
+
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "_"), "_")
+
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+
+	return name
+}
+
+func toolDescription(method, path string, op *Operation) string {
+	var parts []string
+
+	for _, s := range []string{op.Summary, op.Description} {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	if len(parts) == 0 {
+		return method + " " + path
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// requestContent returns the first JSON media type of the content if any, otherwise the first media type.
+func requestContent(content map[string]MediaType) (string, MediaType) {
+	types := slices.Sorted(maps.Keys(content))
+
+	for _, contentType := range types {
+		if isJSON(contentType) {
+			return contentType, content[contentType]
+		}
+	}
+
+	if len(types) == 0 {
+		return "application/json", MediaType{}
+	}
+
+	return types[0], content[types[0]]
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func formatArgument(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, _ := json.Marshal(v)
+
+		return string(data)
+	}
+}
@@ -0,0 +1,153 @@
+// Package moderation provides an llm.Provider that screens content through an llm.Moderator.
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.Provider = (*Provider)(nil)
+
+// ErrUnexpectedResults is returned when the moderator does not return one result per input.
+var ErrUnexpectedResults = errors.New("unexpected number of moderation results")
+
+// Provider is an llm.Provider that moderates the human messages before they
+// are sent to the wrapped provider, and optionally the generated output.
+//
+// An llm.ModerationError is returned when any content is flagged.
+type Provider struct {
+	// Next is the provider called when the input passed moderation.
+	Next llm.Provider
+
+	// Moderator used to classify the content.
+	Moderator llm.Moderator
+
+	// ModerateOutput is true if the generated output should also be moderated.
+	ModerateOutput bool
+
+	// Hooks is called with any moderation errors.
+	Hooks llm.ProviderHooks
+}
+
+// New creates a new moderation Provider wrapping the next provider.
+func New(next llm.Provider, moderator llm.Moderator, options ...func(*Provider)) *Provider {
+	p := &Provider{
+		Next:      next,
+		Moderator: moderator,
+	}
+
+	for _, opt := range options {
+		opt(p)
+	}
+
+	return p
+}
+
+// WithModerateOutput makes the Provider also moderate the generated output.
+func WithModerateOutput() func(*Provider) {
+	return func(p *Provider) {
+		p.ModerateOutput = true
+	}
+}
+
+// WithHooks allows setting hooks that are called with any moderation errors.
+func WithHooks(hooks llm.ProviderHooks) func(*Provider) {
+	return func(p *Provider) {
+		p.Hooks = hooks
+	}
+}
+
+// Call requests a completion for the given prompt.
+func (p *Provider) Call(ctx context.Context, prompt string, options ...llm.ContentOption) (string, error) {
+	return llm.Call(ctx, p, prompt, options...)
+}
+
+// GenerateContent moderates the human messages, calls the next provider and
+// then moderates the output if ModerateOutput is true.
+func (p *Provider) GenerateContent(ctx context.Context, messages []llm.Message, options ...llm.ContentOption) (*llm.ContentResponse, error) {
+	if err := p.moderate(ctx, humanTexts(messages)); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.Next.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.ModerateOutput {
+		outputs := make([]string, 0, len(resp.Choices))
+
+		for _, c := range resp.Choices {
+			if c.Content != "" {
+				outputs = append(outputs, c.Content)
+			}
+		}
+
+		if err := p.moderate(ctx, outputs); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (p *Provider) moderate(ctx context.Context, inputs []string) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	results, err := p.Moderator.Moderate(ctx, inputs)
+	if err != nil {
+		return err
+	}
+
+	if len(results) != len(inputs) {
+		err := fmt.Errorf("%w: got %d for %d inputs", ErrUnexpectedResults, len(results), len(inputs))
+
+		if p.Hooks != nil {
+			p.Hooks.ProviderError(ctx, err)
+		}
+
+		return err
+	}
+
+	for i, r := range results {
+		if !r.Flagged {
+			continue
+		}
+
+		err := llm.ModerationError{
+			Input:  inputs[i],
+			Result: r,
+		}
+
+		if p.Hooks != nil {
+			p.Hooks.ProviderError(ctx, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func humanTexts(messages []llm.Message) []string {
+	texts := []string{}
+
+	for _, m := range messages {
+		if m.Role != llm.ChatMessageTypeHuman && m.Role != llm.ChatMessageTypeGeneric {
+			continue
+		}
+
+		for _, part := range m.Parts {
+			if tc, ok := part.(llm.TextContent); ok && tc.Text != "" {
+				texts = append(texts, tc.Text)
+			}
+		}
+	}
+
+	return texts
+}
@@ -0,0 +1,109 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+func TestProviderGenerateContent(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		called = false
+
+		next = mock.Provider{
+			GenerateContentFunc: func(context.Context, []llm.Message, ...llm.ContentOption) (*llm.ContentResponse, error) {
+				called = true
+
+				return &llm.ContentResponse{
+					Choices: []*llm.ContentChoice{{Content: "bad output"}},
+				}, nil
+			},
+		}
+
+		moderator = mock.Moderator{
+			ModerateFunc: func(_ context.Context, inputs []string) ([]llm.ModerationResult, error) {
+				results := make([]llm.ModerationResult, len(inputs))
+
+				for i, input := range inputs {
+					if input == "bad input" || input == "bad output" {
+						results[i] = llm.ModerationResult{
+							Flagged:    true,
+							Categories: map[string]bool{"violence": true, "hate": false},
+						}
+					}
+				}
+
+				return results, nil
+			},
+		}
+	)
+
+	t.Run("flagged input", func(t *testing.T) {
+		called = false
+
+		_, err := New(next, moderator).Call(ctx, "bad input")
+
+		var modErr llm.ModerationError
+
+		if !errors.As(err, &modErr) {
+			t.Fatalf("expected llm.ModerationError, got %v", err)
+		}
+
+		if !errors.Is(err, llm.ErrContentFlagged) {
+			t.Fatalf("expected errors.Is(err, llm.ErrContentFlagged)")
+		}
+
+		if got, want := err.Error(), "content flagged by moderation: violence"; got != want {
+			t.Fatalf("err.Error() = %q, want %q", got, want)
+		}
+
+		if called {
+			t.Fatalf("expected next provider not to be called")
+		}
+	})
+
+	t.Run("unmoderated output", func(t *testing.T) {
+		got, err := New(next, moderator).Call(ctx, "good input")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want := "bad output"; got != want {
+			t.Fatalf("Call() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("flagged output", func(t *testing.T) {
+		_, err := New(next, moderator, WithModerateOutput()).Call(ctx, "good input")
+
+		if !errors.Is(err, llm.ErrContentFlagged) {
+			t.Fatalf("expected errors.Is(err, llm.ErrContentFlagged), got %v", err)
+		}
+	})
+}
+
+func TestProviderUnexpectedResults(t *testing.T) {
+	next := mock.Provider{
+		GenerateContentFunc: func(context.Context, []llm.Message, ...llm.ContentOption) (*llm.ContentResponse, error) {
+			t.Fatalf("expected next provider not to be called")
+
+			return nil, nil
+		},
+	}
+
+	for _, n := range []int{0, 2} {
+		moderator := mock.Moderator{
+			ModerateFunc: func(context.Context, []string) ([]llm.ModerationResult, error) {
+				return make([]llm.ModerationResult, n), nil
+			},
+		}
+
+		if _, err := New(next, moderator).Call(context.Background(), "input"); !errors.Is(err, ErrUnexpectedResults) {
+			t.Fatalf("%d results: err = %v, want %v", n, err, ErrUnexpectedResults)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package ollama
+
+import (
+	"context"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/providers/ollama/internal/ollama"
+)
+
+// GenerateRequest is a completion-style request sent to /api/generate.
+type GenerateRequest struct {
+	// Prompt to generate a response for.
+	Prompt string
+
+	// Suffix is the text after the response, used for fill-in-the-middle.
+	Suffix string
+
+	// Images to include with the prompt, for multimodal models.
+	Images [][]byte
+
+	// System overrides the system prompt set by WithSystemPrompt.
+	System string
+
+	// Template overrides the template set by WithCustomTemplate.
+	Template string
+
+	// Raw disables all formatting of the prompt, for when the
+	// prompt is already fully templated.
+	Raw bool
+
+	// Context returned by a previous call to Generate,
+	// used to keep a short conversational memory.
+	Context []int
+}
+
+// GenerateResponse is the response from Generate.
+type GenerateResponse = ollama.GenerateResponse
+
+// Generate generates a completion for the prompt in the request, without using the chat endpoint.
+// The Context in the response can be used in the next request to continue the conversation.
+// The hooks are called with the system prompt and prompt as messages, and the response as a choice.
+func (p *Provider) Generate(ctx context.Context, r GenerateRequest, options ...llm.ContentOption) (*GenerateResponse, error) {
+	opts := llm.ContentOptions{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	model := p.model
+
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	format := p.format
+
+	if opts.JSONMode {
+		format = "json"
+	}
+
+	stream := opts.StreamingFunc != nil
+
+	req := &ollama.GenerateRequest{
+		Model:     model,
+		Prompt:    r.Prompt,
+		Suffix:    r.Suffix,
+		System:    r.System,
+		Template:  r.Template,
+		Context:   r.Context,
+		Raw:       r.Raw,
+		Format:    format,
+		Stream:    &stream,
+		KeepAlive: p.keepAlive,
+		Options:   makeOllamaOptions(p.ollamaOptions, opts),
+	}
+
+	if req.System == "" {
+		req.System = p.system
+	}
+
+	if req.Template == "" {
+		req.Template = p.customModelTemplate
+	}
+
+	for _, image := range r.Images {
+		req.Images = append(req.Images, ollama.ImageData(image))
+	}
+
+	if p.hooks != nil {
+		p.hooks.ProviderGenerateContentStart(ctx, generateMessages(req.System, r))
+	}
+
+	var (
+		resp     ollama.GenerateResponse
+		response string
+	)
+
+	fn := func(r ollama.GenerateResponse) error {
+		if opts.StreamingFunc != nil {
+			if err := opts.StreamingFunc(ctx, []byte(r.Response)); err != nil {
+				return err
+			}
+		}
+
+		response += r.Response
+
+		if !stream || r.Done {
+			resp = r
+			resp.Response = response
+		}
+
+		return nil
+	}
+
+	if err := p.client.Generate(ctx, req, fn); err != nil {
+		if p.hooks != nil {
+			p.hooks.ProviderError(ctx, err)
+		}
+
+		return nil, err
+	}
+
+	if p.hooks != nil {
+		p.hooks.ProviderGenerateContentEnd(ctx, &llm.ContentResponse{
+			Choices: []*llm.ContentChoice{
+				{
+					Content:        resp.Response,
+					StopReason:     resp.DoneReason,
+					GenerationInfo: makeGenerationInfo(resp.Metrics),
+				},
+			},
+		})
+	}
+
+	return &resp, nil
+}
+
+// generateMessages returns the system prompt and the prompt of the request as messages.
+func generateMessages(system string, r GenerateRequest) []llm.Message {
+	var messages []llm.Message
+
+	if system != "" {
+		messages = append(messages, llm.TextParts(llm.ChatMessageTypeSystem, system))
+	}
+
+	human := llm.TextParts(llm.ChatMessageTypeHuman, r.Prompt)
+
+	for _, image := range r.Images {
+		human.Parts = append(human.Parts, llm.BinaryPart(llm.DetectMIMEType(image, ""), image))
+	}
+
+	return append(messages, human)
+}
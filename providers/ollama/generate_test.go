@@ -0,0 +1,104 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+func TestProviderGenerate(t *testing.T) {
+	var (
+		path string
+		req  map[string]any
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		w.Write([]byte(`{"response":"x + y","done":true,"done_reason":"stop","context":[1,2,3]}` + "\n"))
+	}))
+	defer srv.Close()
+
+	var (
+		messages []llm.Message
+		response *llm.ContentResponse
+	)
+
+	p, err := New(WithServerURL(srv.URL), WithModel("codellama"), WithSystemPrompt("You are a coder"),
+		WithHooks(mock.Hooks{
+			ProviderGenerateContentStartFunc: func(_ context.Context, ms []llm.Message) {
+				messages = ms
+			},
+			ProviderGenerateContentEndFunc: func(_ context.Context, res *llm.ContentResponse) {
+				response = res
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := p.Generate(context.Background(), GenerateRequest{
+		Prompt: "def add(x, y):",
+		Suffix: "return result",
+		Raw:    true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := path, "/api/generate"; got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+
+	if got, want := req["suffix"], "return result"; got != want {
+		t.Fatalf(`req["suffix"] = %v, want %v`, got, want)
+	}
+
+	if got, want := req["raw"], true; got != want {
+		t.Fatalf(`req["raw"] = %v, want %v`, got, want)
+	}
+
+	if got, want := req["system"], "You are a coder"; got != want {
+		t.Fatalf(`req["system"] = %v, want %v`, got, want)
+	}
+
+	if got, want := resp.Response, "x + y"; got != want {
+		t.Fatalf("resp.Response = %q, want %q", got, want)
+	}
+
+	if got, want := len(resp.Context), 3; got != want {
+		t.Fatalf("len(resp.Context) = %d, want %d", got, want)
+	}
+
+	wantMessages := []llm.Message{
+		llm.TextParts(llm.ChatMessageTypeSystem, "You are a coder"),
+		llm.TextParts(llm.ChatMessageTypeHuman, "def add(x, y):"),
+	}
+
+	if !reflect.DeepEqual(messages, wantMessages) {
+		t.Fatalf("messages = %#v, want %#v", messages, wantMessages)
+	}
+
+	if response == nil || len(response.Choices) != 1 {
+		t.Fatalf("response = %#v, want a single choice", response)
+	}
+
+	if got, want := response.Choices[0].Content, "x + y"; got != want {
+		t.Fatalf("response.Choices[0].Content = %q, want %q", got, want)
+	}
+
+	if got, want := response.Choices[0].StopReason, "stop"; got != want {
+		t.Fatalf("response.Choices[0].StopReason = %q, want %q", got, want)
+	}
+}
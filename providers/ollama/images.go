@@ -0,0 +1,105 @@
+package ollama
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/peterhellberg/llm/providers/ollama/internal/ollama"
+)
+
+// fetchImage resolves the image data of an http(s) or data: URL.
+func (p *Provider) fetchImage(ctx context.Context, rawURL string) (ollama.ImageData, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedImageURL, err)
+	}
+
+	switch u.Scheme {
+	case "data":
+		return p.decodeDataURL(rawURL)
+	case "http", "https":
+		return p.downloadImage(ctx, u)
+	default:
+		return nil, fmt.Errorf("%w: unsupported scheme %q", ErrUnsupportedImageURL, u.Scheme)
+	}
+}
+
+// decodeDataURL decodes a data URL, such as "data:image/png;base64,iVBORw0KGgo...".
+func (p *Provider) decodeDataURL(rawURL string) (ollama.ImageData, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(rawURL, "data:"), ",")
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed data URL", ErrUnsupportedImageURL)
+	}
+
+	if int64(base64.StdEncoding.DecodedLen(len(payload))) > p.maxImageSize+2 {
+		return nil, ErrImageTooLarge
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if strings.HasSuffix(header, ";base64") {
+		data, err = base64.StdEncoding.DecodeString(payload)
+	} else {
+		var unescaped string
+
+		unescaped, err = url.PathUnescape(payload)
+		data = []byte(unescaped)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedImageURL, err)
+	}
+
+	if int64(len(data)) > p.maxImageSize {
+		return nil, ErrImageTooLarge
+	}
+
+	return data, nil
+}
+
+// downloadImage downloads the image using the configured HTTP client.
+func (p *Provider) downloadImage(ctx context.Context, u *url.URL) (ollama.ImageData, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := p.httpClient
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status code %d fetching %s", ErrUnsupportedImageURL, resp.StatusCode, u.Redacted())
+	}
+
+	if resp.ContentLength > p.maxImageSize {
+		return nil, ErrImageTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, p.maxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > p.maxImageSize {
+		return nil, ErrImageTooLarge
+	}
+
+	return data, nil
+}
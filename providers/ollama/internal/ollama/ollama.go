@@ -78,6 +78,80 @@ func (c *Client) CreateEmbedding(ctx context.Context, req *EmbeddingRequest) (*E
 	return resp, nil
 }
 
+func (c *Client) Embed(ctx context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+	resp := &EmbedResponse{}
+
+	if err := c.do(ctx, http.MethodPost, "/api/embed", req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Client) List(ctx context.Context) (*ListResponse, error) {
+	resp := &ListResponse{}
+
+	if err := c.do(ctx, http.MethodGet, "/api/tags", nil, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Client) Show(ctx context.Context, req *ShowRequest) (*ShowResponse, error) {
+	resp := &ShowResponse{}
+
+	if err := c.do(ctx, http.MethodPost, "/api/show", req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+type ProgressResponseFunc func(ProgressResponse) error
+
+func (c *Client) Pull(ctx context.Context, req *PullRequest, fn ProgressResponseFunc) error {
+	return c.stream(ctx, http.MethodPost, "/api/pull", req, progressFunc(fn))
+}
+
+func (c *Client) Create(ctx context.Context, req *CreateRequest, fn ProgressResponseFunc) error {
+	return c.stream(ctx, http.MethodPost, "/api/create", req, progressFunc(fn))
+}
+
+func (c *Client) Copy(ctx context.Context, req *CopyRequest) error {
+	return c.do(ctx, http.MethodPost, "/api/copy", req, nil)
+}
+
+func (c *Client) Delete(ctx context.Context, req *DeleteRequest) error {
+	return c.do(ctx, http.MethodDelete, "/api/delete", req, nil)
+}
+
+func (c *Client) ListRunning(ctx context.Context) (*ProcessResponse, error) {
+	resp := &ProcessResponse{}
+
+	if err := c.do(ctx, http.MethodGet, "/api/ps", nil, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func progressFunc(fn ProgressResponseFunc) func([]byte) error {
+	return func(data []byte) error {
+		var resp ProgressResponse
+
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return err
+		}
+
+		if fn == nil {
+			return nil
+		}
+
+		return fn(resp)
+	}
+}
+
 func (c *Client) stream(ctx context.Context, method, path string, req any, fn func([]byte) error) error {
 	var body io.Reader
 
@@ -1,6 +1,7 @@
 package ollama
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"time"
@@ -27,13 +28,17 @@ func (e StatusError) Error() string {
 }
 
 type GenerateRequest struct {
-	Model     string `json:"model"`
-	Prompt    string `json:"prompt"`
-	System    string `json:"system"`
-	Template  string `json:"template"`
-	Context   []int  `json:"context,omitempty"`
-	Stream    *bool  `json:"stream"`
-	KeepAlive string `json:"keep_alive,omitempty"`
+	Model     string      `json:"model"`
+	Prompt    string      `json:"prompt"`
+	Suffix    string      `json:"suffix,omitempty"`
+	System    string      `json:"system,omitempty"`
+	Template  string      `json:"template,omitempty"`
+	Context   []int       `json:"context,omitempty"`
+	Stream    *bool       `json:"stream"`
+	Raw       bool        `json:"raw,omitempty"`
+	Format    string      `json:"format,omitempty"`
+	Images    []ImageData `json:"images,omitempty"`
+	KeepAlive string      `json:"keep_alive,omitempty"`
 
 	Options Options `json:"options"`
 }
@@ -41,17 +46,42 @@ type GenerateRequest struct {
 type ImageData []byte
 
 type Message struct {
-	Role    string      `json:"role"` // one of ["system", "user", "assistant"]
-	Content string      `json:"content"`
-	Images  []ImageData `json:"images,omitempty"`
+	Role      string      `json:"role"` // one of ["system", "user", "assistant", "tool"]
+	Content   string      `json:"content"`
+	Images    []ImageData `json:"images,omitempty"`
+	ToolCalls []ToolCall  `json:"tool_calls,omitempty"`
+	ToolName  string      `json:"tool_name,omitempty"`
+}
+
+type ToolCall struct {
+	ID       string           `json:"id,omitempty"`
+	Function ToolCallFunction `json:"function"`
+}
+
+type ToolCallFunction struct {
+	Index     int             `json:"index,omitempty"`
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+type Tool struct {
+	Type     string       `json:"type"`
+	Function ToolFunction `json:"function"`
+}
+
+type ToolFunction struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Parameters  any    `json:"parameters,omitempty"`
 }
 
 type ChatRequest struct {
 	Model     string     `json:"model"`
 	Messages  []*Message `json:"messages"`
-	Stream    bool       `json:"stream,omitempty"`
+	Stream    bool       `json:"stream"`
 	Format    string     `json:"format"`
 	KeepAlive string     `json:"keep_alive,omitempty"`
+	Tools     []Tool     `json:"tools,omitempty"`
 
 	Options Options `json:"options"`
 }
@@ -76,18 +106,33 @@ type EmbeddingResponse struct {
 	Embedding []float32 `json:"embedding"`
 }
 
+type EmbedRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Truncate   *bool    `json:"truncate,omitempty"`
+	Dimensions int      `json:"dimensions,omitempty"`
+	KeepAlive  string   `json:"keep_alive,omitempty"`
+	Options    Options  `json:"options"`
+}
+
+type EmbedResponse struct {
+	Model      string      `json:"model"`
+	Embeddings [][]float32 `json:"embeddings"`
+
+	TotalDuration   time.Duration `json:"total_duration,omitempty"`
+	LoadDuration    time.Duration `json:"load_duration,omitempty"`
+	PromptEvalCount int           `json:"prompt_eval_count,omitempty"`
+}
+
 type GenerateResponse struct {
-	CreatedAt          time.Time     `json:"created_at"`
-	Model              string        `json:"model"`
-	Response           string        `json:"response"`
-	Context            []int         `json:"context,omitempty"`
-	TotalDuration      time.Duration `json:"total_duration,omitempty"`
-	LoadDuration       time.Duration `json:"load_duration,omitempty"`
-	PromptEvalCount    int           `json:"prompt_eval_count,omitempty"`
-	PromptEvalDuration time.Duration `json:"prompt_eval_duration,omitempty"`
-	EvalCount          int           `json:"eval_count,omitempty"`
-	EvalDuration       time.Duration `json:"eval_duration,omitempty"`
-	Done               bool          `json:"done"`
+	CreatedAt  time.Time `json:"created_at"`
+	Model      string    `json:"model"`
+	Response   string    `json:"response"`
+	Context    []int     `json:"context,omitempty"`
+	Done       bool      `json:"done"`
+	DoneReason string    `json:"done_reason,omitempty"`
+
+	Metrics
 }
 
 type ChatResponse struct {
@@ -95,11 +140,30 @@ type ChatResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 	Message   *Message  `json:"message,omitempty"`
 
-	Done bool `json:"done"`
+	Done       bool   `json:"done"`
+	DoneReason string `json:"done_reason,omitempty"`
 
 	Metrics
 }
 
+// PromptTokensPerSecond returns the number of prompt tokens evaluated per second.
+func (m Metrics) PromptTokensPerSecond() float64 {
+	if m.PromptEvalDuration <= 0 {
+		return 0
+	}
+
+	return float64(m.PromptEvalCount) / m.PromptEvalDuration.Seconds()
+}
+
+// TokensPerSecond returns the number of tokens generated per second.
+func (m Metrics) TokensPerSecond() float64 {
+	if m.EvalDuration <= 0 {
+		return 0
+	}
+
+	return float64(m.EvalCount) / m.EvalDuration.Seconds()
+}
+
 func (r *GenerateResponse) Summary(w io.Writer) {
 	if r.TotalDuration > 0 {
 		fmt.Fprintf(w, "total duration:       %v\n", r.TotalDuration)
@@ -115,8 +179,7 @@ func (r *GenerateResponse) Summary(w io.Writer) {
 
 	if r.PromptEvalDuration > 0 {
 		fmt.Fprintf(w, "prompt eval duration: %s\n", r.PromptEvalDuration)
-		fmt.Fprintf(w, "prompt eval rate:     %.2f tokens/s\n",
-			float64(r.PromptEvalCount)/r.PromptEvalDuration.Seconds())
+		fmt.Fprintf(w, "prompt eval rate:     %.2f tokens/s\n", r.PromptTokensPerSecond())
 	}
 
 	if r.EvalCount > 0 {
@@ -125,7 +188,7 @@ func (r *GenerateResponse) Summary(w io.Writer) {
 
 	if r.EvalDuration > 0 {
 		fmt.Fprintf(w, "eval duration:        %s\n", r.EvalDuration)
-		fmt.Fprintf(w, "eval rate:            %.2f tokens/s\n", float64(r.EvalCount)/r.EvalDuration.Seconds())
+		fmt.Fprintf(w, "eval rate:            %.2f tokens/s\n", r.TokensPerSecond())
 	}
 }
 
@@ -168,3 +231,96 @@ type Options struct {
 	TopP             float32 `json:"top_p,omitempty"`
 	PenalizeNewline  bool    `json:"penalize_newline,omitempty"`
 }
+
+type ModelDetails struct {
+	ParentModel       string   `json:"parent_model,omitempty"`
+	Format            string   `json:"format,omitempty"`
+	Family            string   `json:"family,omitempty"`
+	Families          []string `json:"families,omitempty"`
+	ParameterSize     string   `json:"parameter_size,omitempty"`
+	QuantizationLevel string   `json:"quantization_level,omitempty"`
+}
+
+type ListModelResponse struct {
+	Name       string       `json:"name"`
+	Model      string       `json:"model"`
+	ModifiedAt time.Time    `json:"modified_at"`
+	Size       int64        `json:"size"`
+	Digest     string       `json:"digest"`
+	Details    ModelDetails `json:"details,omitempty"`
+}
+
+type ListResponse struct {
+	Models []ListModelResponse `json:"models"`
+}
+
+type ShowRequest struct {
+	Model   string `json:"model"`
+	Verbose bool   `json:"verbose,omitempty"`
+}
+
+type ShowResponse struct {
+	License      string         `json:"license,omitempty"`
+	Modelfile    string         `json:"modelfile,omitempty"`
+	Parameters   string         `json:"parameters,omitempty"`
+	Template     string         `json:"template,omitempty"`
+	System       string         `json:"system,omitempty"`
+	Details      ModelDetails   `json:"details,omitempty"`
+	ModelInfo    map[string]any `json:"model_info,omitempty"`
+	Capabilities []string       `json:"capabilities,omitempty"`
+	ModifiedAt   time.Time      `json:"modified_at,omitempty"`
+}
+
+type ProgressResponse struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+}
+
+type PullRequest struct {
+	Model    string `json:"model"`
+	Insecure bool   `json:"insecure,omitempty"`
+	Stream   *bool  `json:"stream,omitempty"`
+}
+
+type CopyRequest struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+}
+
+type DeleteRequest struct {
+	Model string `json:"model"`
+}
+
+type CreateRequest struct {
+	Model      string            `json:"model"`
+	From       string            `json:"from,omitempty"`
+	Files      map[string]string `json:"files,omitempty"`
+	Adapters   map[string]string `json:"adapters,omitempty"`
+	Template   string            `json:"template,omitempty"`
+	License    []string          `json:"license,omitempty"`
+	System     string            `json:"system,omitempty"`
+	Parameters map[string]any    `json:"parameters,omitempty"`
+	Messages   []Message         `json:"messages,omitempty"`
+	Quantize   string            `json:"quantize,omitempty"`
+	Stream     *bool             `json:"stream,omitempty"`
+
+	// Modelfile is only used by Ollama versions before v0.5.5
+	Modelfile string `json:"modelfile,omitempty"`
+}
+
+type ProcessModelResponse struct {
+	Name          string       `json:"name"`
+	Model         string       `json:"model"`
+	Size          int64        `json:"size"`
+	Digest        string       `json:"digest"`
+	Details       ModelDetails `json:"details,omitempty"`
+	ExpiresAt     time.Time    `json:"expires_at"`
+	SizeVRAM      int64        `json:"size_vram"`
+	ContextLength int          `json:"context_length,omitempty"`
+}
+
+type ProcessResponse struct {
+	Models []ProcessModelResponse `json:"models"`
+}
@@ -0,0 +1,85 @@
+package ollama
+
+import (
+	"context"
+
+	"github.com/peterhellberg/llm/providers/ollama/internal/ollama"
+)
+
+// Progress is a progress update streamed while pulling or creating a model.
+type Progress = ollama.ProgressResponse
+
+// ProgressFunc is called for each progress update. Return an error to stop early.
+type ProgressFunc func(Progress) error
+
+// ModelDetails are the details of a model, such as its family and quantization level.
+type ModelDetails = ollama.ModelDetails
+
+// ListResponse is the response from listing the local models.
+type ListResponse = ollama.ListResponse
+
+// ListModelResponse is a single local model.
+type ListModelResponse = ollama.ListModelResponse
+
+// ShowResponse is the response from showing the information about a model.
+type ShowResponse = ollama.ShowResponse
+
+// ProcessResponse is the response from listing the running models.
+type ProcessResponse = ollama.ProcessResponse
+
+// ProcessModelResponse is a single running model.
+type ProcessModelResponse = ollama.ProcessModelResponse
+
+// CreateRequest is a request to create a model.
+type CreateRequest = ollama.CreateRequest
+
+// Pull downloads a model from the Ollama library. Progress updates are
+// sent to fn, which can be nil.
+func (p *Provider) Pull(ctx context.Context, model string, fn ProgressFunc) error {
+	return p.client.Pull(ctx, &ollama.PullRequest{Model: model}, ollama.ProgressResponseFunc(fn))
+}
+
+// List lists the models that are available locally.
+func (p *Provider) List(ctx context.Context) (*ListResponse, error) {
+	return p.client.List(ctx)
+}
+
+// Show shows information about a model, including details, Modelfile,
+// template, parameters, license and system prompt.
+func (p *Provider) Show(ctx context.Context, model string) (*ShowResponse, error) {
+	return p.client.Show(ctx, &ollama.ShowRequest{Model: model})
+}
+
+// Copy creates a model with another name from an existing model.
+func (p *Provider) Copy(ctx context.Context, source, destination string) error {
+	return p.client.Copy(ctx, &ollama.CopyRequest{
+		Source:      source,
+		Destination: destination,
+	})
+}
+
+// Delete deletes a model and its data.
+func (p *Provider) Delete(ctx context.Context, model string) error {
+	return p.client.Delete(ctx, &ollama.DeleteRequest{Model: model})
+}
+
+// Create creates a model. Progress updates are sent to fn, which can be nil.
+func (p *Provider) Create(ctx context.Context, req *CreateRequest, fn ProgressFunc) error {
+	return p.client.Create(ctx, req, ollama.ProgressResponseFunc(fn))
+}
+
+// CreateFromModelfile creates a model from the contents of a Modelfile.
+// Progress updates are sent to fn, which can be nil.
+func (p *Provider) CreateFromModelfile(ctx context.Context, model, modelfile string, fn ProgressFunc) error {
+	req, err := ParseModelfile(model, modelfile)
+	if err != nil {
+		return err
+	}
+
+	return p.Create(ctx, req, fn)
+}
+
+// ListRunning lists the models that are currently loaded into memory.
+func (p *Provider) ListRunning(ctx context.Context) (*ProcessResponse, error) {
+	return p.client.ListRunning(ctx)
+}
@@ -0,0 +1,170 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type recordedRequest struct {
+	method string
+	path   string
+	body   map[string]any
+}
+
+// newManagementServer returns a server that records the requests, and responds with the body for the path.
+func newManagementServer(t *testing.T, responses map[string]string) (*httptest.Server, *[]recordedRequest) {
+	t.Helper()
+
+	var requests []recordedRequest
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := recordedRequest{method: r.Method, path: r.URL.Path}
+
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &req.body); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+
+		requests = append(requests, req)
+
+		w.Write([]byte(responses[r.URL.Path]))
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestProviderManagement(t *testing.T) {
+	srv, requests := newManagementServer(t, map[string]string{
+		"/api/tags": `{"models":[{"name":"llama3:latest","model":"llama3:latest","size":42,` +
+			`"details":{"family":"llama","parameter_size":"8B"}}]}`,
+		"/api/show": `{"template":"{{ .Prompt }}","parameters":"num_ctx 4096",` +
+			`"details":{"family":"llama"},"capabilities":["completion","tools"]}`,
+		"/api/ps": `{"models":[{"name":"llama3:latest","model":"llama3:latest","size_vram":1024}]}`,
+	})
+
+	p, err := New(WithServerURL(srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	list, err := p.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := list.Models[0].Details.ParameterSize, "8B"; got != want {
+		t.Fatalf("ParameterSize = %q, want %q", got, want)
+	}
+
+	show, err := p.Show(ctx, "llama3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := show.Capabilities, []string{"completion", "tools"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Capabilities = %q, want %q", got, want)
+	}
+
+	if err := p.Copy(ctx, "llama3", "llama3-backup"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := p.Delete(ctx, "llama3-backup"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	running, err := p.ListRunning(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := running.Models[0].Name, "llama3:latest"; got != want {
+		t.Fatalf("Name = %q, want %q", got, want)
+	}
+
+	want := []recordedRequest{
+		{method: http.MethodGet, path: "/api/tags"},
+		{method: http.MethodPost, path: "/api/show", body: map[string]any{"model": "llama3"}},
+		{method: http.MethodPost, path: "/api/copy", body: map[string]any{"source": "llama3", "destination": "llama3-backup"}},
+		{method: http.MethodDelete, path: "/api/delete", body: map[string]any{"model": "llama3-backup"}},
+		{method: http.MethodGet, path: "/api/ps"},
+	}
+
+	if !reflect.DeepEqual(*requests, want) {
+		t.Fatalf("requests = %+v, want %+v", *requests, want)
+	}
+}
+
+func TestProviderPullAndCreate(t *testing.T) {
+	progress := `{"status":"pulling manifest"}` + "\n" +
+		`{"status":"downloading","digest":"sha256:abc","total":100,"completed":50}` + "\n" +
+		`{"status":"success"}` + "\n"
+
+	srv, requests := newManagementServer(t, map[string]string{
+		"/api/pull":   progress,
+		"/api/create": progress,
+	})
+
+	p, err := New(WithServerURL(srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updates []Progress
+
+	fn := func(p Progress) error {
+		updates = append(updates, p)
+
+		return nil
+	}
+
+	if err := p.Pull(context.Background(), "llama3", fn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := p.CreateFromModelfile(context.Background(), "mario", "FROM llama3\nSYSTEM You are Mario.", fn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := len(updates), 6; got != want {
+		t.Fatalf("len(updates) = %d, want %d", got, want)
+	}
+
+	if got, want := updates[1], (Progress{Status: "downloading", Digest: "sha256:abc", Total: 100, Completed: 50}); got != want {
+		t.Fatalf("updates[1] = %+v, want %+v", got, want)
+	}
+
+	pull, create := (*requests)[0], (*requests)[1]
+
+	if got, want := pull.method+" "+pull.path, "POST /api/pull"; got != want {
+		t.Fatalf("pull = %q, want %q", got, want)
+	}
+
+	if got, want := pull.body["model"], "llama3"; got != want {
+		t.Fatalf(`pull.body["model"] = %v, want %v`, got, want)
+	}
+
+	if got, want := create.method+" "+create.path, "POST /api/create"; got != want {
+		t.Fatalf("create = %q, want %q", got, want)
+	}
+
+	for key, want := range map[string]any{
+		"model":  "mario",
+		"from":   "llama3",
+		"system": "You are Mario.",
+	} {
+		if got := create.body[key]; got != want {
+			t.Fatalf("create.body[%q] = %v, want %v", key, got, want)
+		}
+	}
+}
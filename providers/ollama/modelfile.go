@@ -0,0 +1,144 @@
+package ollama
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/peterhellberg/llm/providers/ollama/internal/ollama"
+)
+
+// ErrInvalidModelfile is returned when a Modelfile could not be parsed.
+var ErrInvalidModelfile = fmt.Errorf("invalid modelfile")
+
+// ParseModelfile parses the FROM, PARAMETER, TEMPLATE, SYSTEM, LICENSE and
+// MESSAGE instructions of a Modelfile into a CreateRequest for the model.
+//
+// The Modelfile itself is also included in the request, for compatibility
+// with Ollama versions before v0.5.5
+//
+// ADAPTER instructions are rejected, since the API refers to adapters by the
+// digest of an uploaded blob rather than by path. Upload the adapter and set
+// the Adapters of the CreateRequest instead.
+func ParseModelfile(model, modelfile string) (*CreateRequest, error) {
+	req := &ollama.CreateRequest{
+		Model:     model,
+		Modelfile: modelfile,
+	}
+
+	rest := modelfile
+
+	for line := 1; rest != ""; line++ {
+		var current string
+
+		current, rest, _ = strings.Cut(rest, "\n")
+		current = strings.TrimSpace(current)
+
+		if current == "" || strings.HasPrefix(current, "#") {
+			continue
+		}
+
+		instruction, args, _ := strings.Cut(current, " ")
+		args = strings.TrimSpace(args)
+
+		value, remaining, err := modelfileValue(args, rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidModelfile, line, err)
+		}
+
+		line += strings.Count(rest, "\n") - strings.Count(remaining, "\n")
+		rest = remaining
+
+		switch strings.ToUpper(instruction) {
+		case "FROM":
+			req.From = value
+		case "TEMPLATE":
+			req.Template = value
+		case "SYSTEM":
+			req.System = value
+		case "LICENSE":
+			req.License = append(req.License, value)
+		case "PARAMETER":
+			name, raw, _ := strings.Cut(value, " ")
+
+			if req.Parameters == nil {
+				req.Parameters = map[string]any{}
+			}
+
+			addModelfileParameter(req.Parameters, name, strings.TrimSpace(raw))
+		case "MESSAGE":
+			role, content, _ := strings.Cut(value, " ")
+
+			req.Messages = append(req.Messages, ollama.Message{
+				Role:    role,
+				Content: unquote(strings.TrimSpace(content)),
+			})
+		case "ADAPTER":
+			return nil, fmt.Errorf("%w: line %d: ADAPTER is not supported, set the Adapters of the CreateRequest",
+				ErrInvalidModelfile, line)
+		default:
+			return nil, fmt.Errorf("%w: line %d: unsupported instruction %q", ErrInvalidModelfile, line, instruction)
+		}
+	}
+
+	if req.From == "" {
+		return nil, fmt.Errorf("%w: missing FROM instruction", ErrInvalidModelfile)
+	}
+
+	return req, nil
+}
+
+// modelfileValue returns the value of an instruction, reading from rest
+// until the closing triple quotes if the value is a multiline string.
+func modelfileValue(args, rest string) (string, string, error) {
+	if !strings.HasPrefix(args, `"""`) {
+		return unquote(args), rest, nil
+	}
+
+	text := args[3:]
+
+	if text == "" {
+		text = rest
+	} else {
+		text += "\n" + rest
+	}
+
+	value, remaining, ok := strings.Cut(text, `"""`)
+	if !ok {
+		return "", "", fmt.Errorf(`missing closing """`)
+	}
+
+	// Discard anything trailing the closing triple quotes on the same line.
+	_, remaining, _ = strings.Cut(remaining, "\n")
+
+	return strings.TrimSuffix(value, "\n"), remaining, nil
+}
+
+func addModelfileParameter(params map[string]any, name, raw string) {
+	value := unquote(raw)
+
+	if name == "stop" {
+		stop, _ := params[name].([]string)
+		params[name] = append(stop, value)
+
+		return
+	}
+
+	if i, err := strconv.Atoi(value); err == nil {
+		params[name] = i
+	} else if f, err := strconv.ParseFloat(value, 64); err == nil {
+		params[name] = f
+	} else if b, err := strconv.ParseBool(value); err == nil {
+		params[name] = b
+	} else {
+		params[name] = value
+	}
+}
+
+func unquote(s string) string {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+
+	return s
+}
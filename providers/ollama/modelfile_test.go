@@ -0,0 +1,57 @@
+package ollama
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseModelfile(t *testing.T) {
+	req, err := ParseModelfile("mario", `# A Modelfile
+FROM llama3.2
+PARAMETER temperature 1
+PARAMETER num_ctx 4096
+PARAMETER stop "<|eot_id|>"
+TEMPLATE """{{ if .System }}{{ .System }}
+{{ end }}{{ .Prompt }}"""
+SYSTEM You are Mario from Super Mario Bros.
+MESSAGE user Is Toronto in Canada?
+MESSAGE assistant yes
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := req.From, "llama3.2"; got != want {
+		t.Fatalf("req.From = %q, want %q", got, want)
+	}
+
+	if got, want := req.Parameters["num_ctx"], 4096; got != want {
+		t.Fatalf(`req.Parameters["num_ctx"] = %v, want %v`, got, want)
+	}
+
+	if got, want := req.Parameters["stop"].([]string)[0], "<|eot_id|>"; got != want {
+		t.Fatalf(`req.Parameters["stop"][0] = %q, want %q`, got, want)
+	}
+
+	if got, want := req.Template, "{{ if .System }}{{ .System }}\n{{ end }}{{ .Prompt }}"; got != want {
+		t.Fatalf("req.Template = %q, want %q", got, want)
+	}
+
+	if got, want := req.System, "You are Mario from Super Mario Bros."; got != want {
+		t.Fatalf("req.System = %q, want %q", got, want)
+	}
+
+	if got, want := len(req.Messages), 2; got != want {
+		t.Fatalf("len(req.Messages) = %d, want %d", got, want)
+	}
+
+	if _, err := ParseModelfile("invalid", "PARAMETER temperature 1"); err == nil {
+		t.Fatalf("expected error for Modelfile without FROM")
+	}
+
+	_, err = ParseModelfile("adapted", "FROM llama3.2\nADAPTER ./lora.gguf")
+	if !errors.Is(err, ErrInvalidModelfile) || !strings.Contains(err.Error(), "Adapters") {
+		t.Fatalf("err = %v, want %v about Adapters", err, ErrInvalidModelfile)
+	}
+}
@@ -0,0 +1,112 @@
+package ollama
+
+import (
+	"bufio"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/providers/ollama/internal/ollama"
+)
+
+var _ llm.ModelLister = (*Provider)(nil)
+
+// ListModels lists the local models using /api/tags, and then uses /api/show
+// to find the context window, capabilities, parameters and template of each model.
+func (p *Provider) ListModels(ctx context.Context) ([]llm.ModelInfo, error) {
+	list, err := p.client.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]llm.ModelInfo, 0, len(list.Models))
+
+	for _, m := range list.Models {
+		show, err := p.client.Show(ctx, &ollama.ShowRequest{Model: m.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, makeModelInfo(m, show))
+	}
+
+	return models, nil
+}
+
+// ParseParameters parses the parameters reported by /api/show, one parameter
+// per line, into a map from parameter name to its values. Parameters such as
+// stop can occur several times.
+func ParseParameters(parameters string) map[string][]string {
+	params := map[string][]string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(parameters))
+
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !ok || key == "" {
+			continue
+		}
+
+		params[key] = append(params[key], unquote(strings.TrimSpace(value)))
+	}
+
+	return params
+}
+
+func makeModelInfo(m ollama.ListModelResponse, show *ollama.ShowResponse) llm.ModelInfo {
+	info := llm.ModelInfo{
+		ID:            m.Name,
+		Type:          llm.ModelTypeChat,
+		ContextWindow: contextLength(show.ModelInfo),
+		Modalities:    []string{"text"},
+		Metadata: map[string]any{
+			"digest":             m.Digest,
+			"size":               m.Size,
+			"modified_at":        m.ModifiedAt,
+			"format":             m.Details.Format,
+			"family":             m.Details.Family,
+			"families":           m.Details.Families,
+			"parameter_size":     m.Details.ParameterSize,
+			"quantization_level": m.Details.QuantizationLevel,
+			"capabilities":       show.Capabilities,
+			"parameters":         ParseParameters(show.Parameters),
+			"template":           show.Template,
+			"system":             show.System,
+		},
+	}
+
+	families := append([]string{m.Details.Family}, m.Details.Families...)
+
+	switch {
+	case slices.Contains(show.Capabilities, "embedding"):
+		info.Type = llm.ModelTypeEmbedding
+	case len(show.Capabilities) == 0 && slices.ContainsFunc(families, isEmbeddingFamily):
+		info.Type = llm.ModelTypeEmbedding
+	}
+
+	if slices.Contains(show.Capabilities, "vision") || slices.ContainsFunc(families, isVisionFamily) {
+		info.Modalities = append(info.Modalities, "image")
+	}
+
+	return info
+}
+
+// contextLength returns the <architecture>.context_length from the model info.
+func contextLength(modelInfo map[string]any) int {
+	arch, _ := modelInfo["general.architecture"].(string)
+
+	if n, ok := modelInfo[arch+".context_length"].(float64); ok {
+		return int(n)
+	}
+
+	return 0
+}
+
+func isEmbeddingFamily(family string) bool {
+	return strings.HasSuffix(family, "bert")
+}
+
+func isVisionFamily(family string) bool {
+	return family == "clip" || family == "mllama"
+}
@@ -0,0 +1,124 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+)
+
+func TestProviderListModels(t *testing.T) {
+	var shown []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tags":
+			w.Write([]byte(`{"models":[
+				{"name":"llava:7b","digest":"abc","size":42,"details":{"family":"llama","families":["llama","clip"]}},
+				{"name":"nomic-embed-text:latest","digest":"def","size":7,"details":{"family":"nomic-bert"}}
+			]}`))
+		case "/api/show":
+			var req struct {
+				Model string `json:"model"`
+			}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			shown = append(shown, req.Model)
+
+			switch req.Model {
+			case "llava:7b":
+				w.Write([]byte(`{"parameters":"num_ctx 4096\nstop \"</s>\"","template":"{{ .Prompt }}",` +
+					`"model_info":{"general.architecture":"llama","llama.context_length":32768},` +
+					`"capabilities":["completion","vision"]}`))
+			default:
+				w.Write([]byte(`{"model_info":{"general.architecture":"nomic-bert","nomic-bert.context_length":2048}}`))
+			}
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := New(WithServerURL(srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	models, err := p.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"llava:7b", "nomic-embed-text:latest"}; !reflect.DeepEqual(shown, want) {
+		t.Fatalf("shown = %q, want %q", shown, want)
+	}
+
+	if got, want := len(models), 2; got != want {
+		t.Fatalf("len(models) = %d, want %d", got, want)
+	}
+
+	llava, embed := models[0], models[1]
+
+	if got, want := llava.Type, llm.ModelTypeChat; got != want {
+		t.Fatalf("llava.Type = %q, want %q", got, want)
+	}
+
+	if got, want := llava.ContextWindow, 32768; got != want {
+		t.Fatalf("llava.ContextWindow = %d, want %d", got, want)
+	}
+
+	if got, want := llava.Modalities, []string{"text", "image"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("llava.Modalities = %q, want %q", got, want)
+	}
+
+	for key, want := range map[string]any{
+		"digest":       "abc",
+		"size":         int64(42),
+		"family":       "llama",
+		"capabilities": []string{"completion", "vision"},
+		"parameters":   map[string][]string{"num_ctx": {"4096"}, "stop": {"</s>"}},
+		"template":     "{{ .Prompt }}",
+	} {
+		if got := llava.Metadata[key]; !reflect.DeepEqual(got, want) {
+			t.Fatalf("llava.Metadata[%q] = %v, want %v", key, got, want)
+		}
+	}
+
+	if got, want := embed.Type, llm.ModelTypeEmbedding; got != want {
+		t.Fatalf("embed.Type = %q, want %q", got, want)
+	}
+
+	if got, want := embed.ContextWindow, 2048; got != want {
+		t.Fatalf("embed.ContextWindow = %d, want %d", got, want)
+	}
+
+	if got, want := embed.Modalities, []string{"text"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("embed.Modalities = %q, want %q", got, want)
+	}
+}
+
+func TestParseParameters(t *testing.T) {
+	params := ParseParameters(`num_ctx                        4096
+stop                           "<|start_header_id|>"
+stop                           "<|eot_id|>"
+`)
+
+	if got, want := len(params), 2; got != want {
+		t.Fatalf("len(params) = %d, want %d", got, want)
+	}
+
+	if got, want := params["num_ctx"][0], "4096"; got != want {
+		t.Fatalf(`params["num_ctx"][0] = %q, want %q`, got, want)
+	}
+
+	if got, want := params["stop"][1], "<|eot_id|>"; got != want {
+		t.Fatalf(`params["stop"][1] = %q, want %q`, got, want)
+	}
+}
@@ -2,7 +2,12 @@ package ollama
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
 
 	"github.com/peterhellberg/llm"
 	"github.com/peterhellberg/llm/providers/ollama/internal/ollama"
@@ -16,8 +21,12 @@ var (
 var (
 	ErrEmptyResponse                    = fmt.Errorf("no response")
 	ErrIncompleteEmbedding              = fmt.Errorf("not all input got embedded")
-	ErrExpectingSingleText              = fmt.Errorf("expecting a single Text content")
-	ErrOnlySupportsTextAndBinaryContent = fmt.Errorf("only supports Text and BinaryContent parts right now")
+	ErrOnlySupportsTextAndBinaryContent = fmt.Errorf("only supports Text, Binary, ImageURL and tool parts right now")
+	ErrUnsupportedImageURL              = fmt.Errorf("unsupported image URL")
+	ErrImageTooLarge                    = fmt.Errorf("image too large")
+
+	// Deprecated: multiple Text content parts are now joined using the separator set by WithTextSeparator.
+	ErrExpectingSingleText = fmt.Errorf("expecting a single Text content")
 )
 
 // Provider is an llm.Provider implementation for Ollama.
@@ -28,7 +37,10 @@ type Provider struct {
 
 // New creates a new ollama llm.Provider implementation.
 func New(options ...Option) (*Provider, error) {
-	o := Options{}
+	o := Options{
+		textSeparator: defaultTextSeparator,
+		maxImageSize:  defaultMaxImageSize,
+	}
 
 	for _, option := range options {
 		if err := option(&o); err != nil {
@@ -85,7 +97,20 @@ func (p *Provider) GenerateContent(ctx context.Context, messages []llm.Message,
 	// We have to convert it to a format Ollama undestands: ChatRequest, which
 	// has a sequence of Message, each of which has a role and content - single
 	// text + potential images.
-	ollamaMessages, err := makeOllamaMessages(messages)
+	ollamaMessages, err := p.makeOllamaMessages(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the system prompt set by WithSystemPrompt, unless
+	// the messages already contain a system message.
+	if p.system != "" && !slices.ContainsFunc(ollamaMessages, isSystemMessage) {
+		ollamaMessages = append([]*ollama.Message{
+			{Role: "system", Content: p.system},
+		}, ollamaMessages...)
+	}
+
+	tools, err := makeOllamaTools(opts.Tools)
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +121,7 @@ func (p *Provider) GenerateContent(ctx context.Context, messages []llm.Message,
 		Messages: ollamaMessages,
 		Options:  ollamaOptions,
 		Stream:   opts.StreamingFunc != nil,
+		Tools:    tools,
 	}
 
 	keepAlive := p.keepAlive
@@ -108,24 +134,39 @@ func (p *Provider) GenerateContent(ctx context.Context, messages []llm.Message,
 
 	streamedResponse := ""
 
-	var resp ollama.ChatResponse
+	var (
+		resp      ollama.ChatResponse
+		toolCalls []ollama.ToolCall
+	)
 
 	fn = func(response ollama.ChatResponse) error {
 		if opts.StreamingFunc != nil && response.Message != nil {
-			if err := opts.StreamingFunc(ctx, []byte(response.Message.Content)); err != nil {
+			chunk := []byte(response.Message.Content)
+
+			if len(response.Message.ToolCalls) > 0 {
+				var err error
+
+				if chunk, err = json.Marshal(response.Message.ToolCalls); err != nil {
+					return err
+				}
+			}
+
+			if err := opts.StreamingFunc(ctx, chunk); err != nil {
 				return err
 			}
 		}
 
 		if response.Message != nil {
 			streamedResponse += response.Message.Content
+			toolCalls = append(toolCalls, response.Message.ToolCalls...)
 		}
 
 		if !req.Stream || response.Done {
 			resp = response
 			resp.Message = &ollama.Message{
-				Role:    "assistant",
-				Content: streamedResponse,
+				Role:      "assistant",
+				Content:   streamedResponse,
+				ToolCalls: toolCalls,
 			}
 		}
 		return nil
@@ -141,15 +182,18 @@ func (p *Provider) GenerateContent(ctx context.Context, messages []llm.Message,
 
 	choices := []*llm.ContentChoice{
 		{
-			Content: resp.Message.Content,
-			GenerationInfo: map[string]any{
-				"CompletionTokens": resp.EvalCount,
-				"PromptTokens":     resp.PromptEvalCount,
-				"TotalTokens":      resp.EvalCount + resp.PromptEvalCount,
-			},
+			Content:        resp.Message.Content,
+			StopReason:     resp.DoneReason,
+			GenerationInfo: makeGenerationInfo(resp.Metrics),
+			ToolCalls:      makeToolCalls(resp.Message.ToolCalls),
 		},
 	}
 
+	// populate legacy single-function call field for backwards compatibility
+	if len(choices[0].ToolCalls) > 0 {
+		choices[0].FuncCall = choices[0].ToolCalls[0].FunctionCall
+	}
+
 	response := &llm.ContentResponse{
 		Choices: choices,
 	}
@@ -161,39 +205,48 @@ func (p *Provider) GenerateContent(ctx context.Context, messages []llm.Message,
 	return response, nil
 }
 
+// CreateEmbedding creates embeddings for all of the inputs in a single request to /api/embed.
+// No request is sent if there are no inputs.
 func (p *Provider) CreateEmbedding(ctx context.Context, inputs []string) ([][]float32, error) {
-	embeddings := [][]float32{}
+	if len(inputs) == 0 {
+		return [][]float32{}, nil
+	}
 
-	for _, input := range inputs {
-		req := &ollama.EmbeddingRequest{
-			Prompt: input,
-			Model:  p.model,
-		}
+	model := p.embeddingModel
 
-		if p.keepAlive != "" {
-			req.KeepAlive = p.keepAlive
-		}
+	if model == "" {
+		model = p.model
+	}
 
-		embedding, err := p.client.CreateEmbedding(ctx, req)
-		if err != nil {
-			return nil, err
-		}
+	req := &ollama.EmbedRequest{
+		Model:      model,
+		Input:      inputs,
+		Truncate:   p.embeddingTruncate,
+		Dimensions: p.embeddingDimensions,
+		Options:    p.ollamaOptions,
+	}
 
-		if len(embedding.Embedding) == 0 {
-			return nil, ErrEmptyResponse
-		}
+	if p.keepAlive != "" {
+		req.KeepAlive = p.keepAlive
+	}
+
+	resp, err := p.client.Embed(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 
-		embeddings = append(embeddings, embedding.Embedding)
+	if len(resp.Embeddings) == 0 {
+		return nil, ErrEmptyResponse
 	}
 
-	if len(inputs) != len(embeddings) {
-		return embeddings, ErrIncompleteEmbedding
+	if len(inputs) != len(resp.Embeddings) {
+		return resp.Embeddings, ErrIncompleteEmbedding
 	}
 
-	return embeddings, nil
+	return resp.Embeddings, nil
 }
 
-func makeOllamaMessages(llmMessages []llm.Message) ([]*ollama.Message, error) {
+func (p *Provider) makeOllamaMessages(ctx context.Context, llmMessages []llm.Message) ([]*ollama.Message, error) {
 	ollamaMessages := make([]*ollama.Message, 0, len(llmMessages))
 
 	for _, mc := range llmMessages {
@@ -201,39 +254,143 @@ func makeOllamaMessages(llmMessages []llm.Message) ([]*ollama.Message, error) {
 			Role: typeToRole(mc.Role),
 		}
 
-		// Look at all the parts in mc; expect to find a single Text part and
-		// any number of binary parts.
-		var text string
-
-		foundText := false
-
-		var images []ollama.ImageData
-
-		for _, p := range mc.Parts {
-			switch pt := p.(type) {
+		// Look at all the parts in mc; join the text parts using the separator,
+		// collect images, tool calls, and tool call responses.
+		var (
+			texts     []string
+			images    []ollama.ImageData
+			toolCalls []ollama.ToolCall
+			toolMsgs  []*ollama.Message
+		)
+
+		for _, part := range mc.Parts {
+			switch pt := part.(type) {
 			case llm.TextContent:
-				if foundText {
-					return nil, ErrExpectingSingleText
+				texts = append(texts, pt.Text)
+			case llm.BinaryContent:
+				if pt.MIMEType != "" && !strings.HasPrefix(pt.MIMEType, "image/") {
+					return nil, fmt.Errorf("%w: %s binary content", llm.ErrUnsupportedContentPart, pt.MIMEType)
 				}
 
-				foundText = true
-				text = pt.Text
-			case llm.BinaryContent:
 				images = append(images, ollama.ImageData(pt.Data))
+			case llm.ImageURLContent:
+				data, err := p.fetchImage(ctx, pt.URL)
+				if err != nil {
+					return nil, err
+				}
+
+				images = append(images, data)
+			case llm.ToolCall:
+				toolCalls = append(toolCalls, makeOllamaToolCall(pt))
+			case llm.ToolCallResponse:
+				toolMsgs = append(toolMsgs, &ollama.Message{
+					Role:     "tool",
+					Content:  pt.Content,
+					ToolName: pt.Name,
+				})
+			case llm.AudioContent, llm.FileContent, llm.AudioOutputContent:
+				return nil, fmt.Errorf("%w: %T", llm.ErrUnsupportedContentPart, part)
 			default:
-				return nil, ErrOnlySupportsTextAndBinaryContent
+				return nil, fmt.Errorf("%w: %T", ErrOnlySupportsTextAndBinaryContent, part)
 			}
 		}
 
-		msg.Content = text
+		msg.Content = strings.Join(texts, p.textSeparator)
 		msg.Images = images
+		msg.ToolCalls = toolCalls
+
+		// Each tool call response is sent as its own tool message.
+		if len(toolMsgs) == 0 || len(texts) > 0 || len(images) > 0 || len(toolCalls) > 0 {
+			ollamaMessages = append(ollamaMessages, msg)
+		}
 
-		ollamaMessages = append(ollamaMessages, msg)
+		ollamaMessages = append(ollamaMessages, toolMsgs...)
 	}
 
 	return ollamaMessages, nil
 }
 
+func makeOllamaTools(tools []llm.Tool) ([]ollama.Tool, error) {
+	ollamaTools := make([]ollama.Tool, 0, len(tools))
+
+	for _, t := range tools {
+		if t.Type != "function" || t.Function == nil {
+			return nil, fmt.Errorf("tool type %v not supported", t.Type)
+		}
+
+		ollamaTools = append(ollamaTools, ollama.Tool{
+			Type: t.Type,
+			Function: ollama.ToolFunction{
+				Name:        t.Function.Name,
+				Description: t.Function.Description,
+				Parameters:  t.Function.Parameters,
+			},
+		})
+	}
+
+	return ollamaTools, nil
+}
+
+func makeOllamaToolCall(tc llm.ToolCall) ollama.ToolCall {
+	call := ollama.ToolCall{
+		ID: tc.ID,
+	}
+
+	if tc.FunctionCall != nil {
+		call.Function.Name = tc.FunctionCall.Name
+
+		if tc.FunctionCall.Arguments != "" {
+			call.Function.Arguments = json.RawMessage(tc.FunctionCall.Arguments)
+		}
+	}
+
+	if len(call.Function.Arguments) == 0 {
+		call.Function.Arguments = json.RawMessage("{}")
+	}
+
+	return call
+}
+
+func makeToolCalls(ollamaToolCalls []ollama.ToolCall) []llm.ToolCall {
+	var toolCalls []llm.ToolCall
+
+	for _, tc := range ollamaToolCalls {
+		id := tc.ID
+
+		// Ollama does not always return an ID for the tool call.
+		if id == "" {
+			id = "call_" + uuid.NewString()
+		}
+
+		toolCalls = append(toolCalls, llm.ToolCall{
+			ID:   id,
+			Type: "function",
+			FunctionCall: &llm.FunctionCall{
+				Name:      tc.Function.Name,
+				Arguments: string(tc.Function.Arguments),
+			},
+		})
+	}
+
+	return toolCalls
+}
+
+// makeGenerationInfo returns the token counts, timings and
+// tokens per second reported by Ollama in the final response.
+func makeGenerationInfo(m ollama.Metrics) map[string]any {
+	return map[string]any{
+		"CompletionTokens":      m.EvalCount,
+		"PromptTokens":          m.PromptEvalCount,
+		"TotalTokens":           m.EvalCount + m.PromptEvalCount,
+		"TotalDuration":         m.TotalDuration,
+		"LoadDuration":          m.LoadDuration,
+		"PromptEvalDuration":    m.PromptEvalDuration,
+		"EvalDuration":          m.EvalDuration,
+		"PromptTokensPerSecond": m.PromptTokensPerSecond(),
+		"TokensPerSecond":       m.TokensPerSecond(),
+	}
+}
+
 func makeOllamaOptions(o ollama.Options, co llm.ContentOptions) ollama.Options {
 	o.NumPredict = co.MaxTokens
 	o.Temperature = float32(co.Temperature)
@@ -248,6 +405,10 @@ func makeOllamaOptions(o ollama.Options, co llm.ContentOptions) ollama.Options {
 	return o
 }
 
+func isSystemMessage(m *ollama.Message) bool {
+	return m.Role == "system"
+}
+
 func typeToRole(cmt llm.ChatMessageType) string {
 	switch cmt {
 	case llm.ChatMessageTypeSystem:
@@ -0,0 +1,405 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peterhellberg/llm"
+)
+
+func TestMakeOllamaMessagesWithTools(t *testing.T) {
+	p, err := New(WithServerURL("http://localhost:11434"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	messages, err := p.makeOllamaMessages(context.Background(), []llm.Message{
+		llm.TextParts(llm.ChatMessageTypeHuman, "What is the weather in Boston?"),
+		{
+			Role: llm.ChatMessageTypeAI,
+			Parts: []llm.ContentPart{
+				llm.ToolCall{
+					ID:   "call_1",
+					Type: "function",
+					FunctionCall: &llm.FunctionCall{
+						Name:      "getWeather",
+						Arguments: `{"location":"Boston"}`,
+					},
+				},
+			},
+		},
+		{
+			Role: llm.ChatMessageTypeTool,
+			Parts: []llm.ContentPart{
+				llm.ToolCallResponse{ToolCallID: "call_1", Name: "getWeather", Content: "72 and sunny"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := len(messages), 3; got != want {
+		t.Fatalf("len(messages) = %d, want %d", got, want)
+	}
+
+	if got, want := string(messages[1].ToolCalls[0].Function.Arguments), `{"location":"Boston"}`; got != want {
+		t.Fatalf("arguments = %q, want %q", got, want)
+	}
+
+	if got, want := messages[2].Role, "tool"; got != want {
+		t.Fatalf("messages[2].Role = %q, want %q", got, want)
+	}
+
+	if got, want := messages[2].ToolName, "getWeather"; got != want {
+		t.Fatalf("messages[2].ToolName = %q, want %q", got, want)
+	}
+}
+
+func TestProviderGenerateContentStreamingMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"message":{"role":"assistant","content":"Hello"},"done":false}` + "\n"))
+		w.Write([]byte(`{"message":{"role":"assistant","content":" world"},"done":false}` + "\n"))
+		w.Write([]byte(`{"message":{"role":"assistant","content":""},"done":true,"done_reason":"stop",` +
+			`"total_duration":3000000000,"load_duration":1000000000,"prompt_eval_count":10,` +
+			`"prompt_eval_duration":500000000,"eval_count":20,"eval_duration":2000000000}` + "\n"))
+	}))
+	defer srv.Close()
+
+	p, err := New(WithServerURL(srv.URL), WithModel("llama3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var chunks int
+
+	resp, err := llm.Content(context.Background(), p, "Hi", llm.WithStreamingFunc(func(context.Context, []byte) error {
+		chunks++
+
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	choice := resp.Choices[0]
+
+	if got, want := choice.Content, "Hello world"; got != want {
+		t.Fatalf("choice.Content = %q, want %q", got, want)
+	}
+
+	if got, want := choice.StopReason, "stop"; got != want {
+		t.Fatalf("choice.StopReason = %q, want %q", got, want)
+	}
+
+	if got, want := chunks, 3; got != want {
+		t.Fatalf("chunks = %d, want %d", got, want)
+	}
+
+	if got, want := choice.GenerationInfo["LoadDuration"], time.Second; got != want {
+		t.Fatalf(`GenerationInfo["LoadDuration"] = %v, want %v`, got, want)
+	}
+
+	if got, want := choice.GenerationInfo["TokensPerSecond"], 10.0; got != want {
+		t.Fatalf(`GenerationInfo["TokensPerSecond"] = %v, want %v`, got, want)
+	}
+}
+
+func TestMakeOllamaMessagesWithMultipleTextAndImageURLs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("fetched"))
+	}))
+	defer srv.Close()
+
+	p, err := New(WithServerURL(srv.URL), WithTextSeparator(" "), WithMaxImageSize(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	messages, err := p.makeOllamaMessages(ctx, []llm.Message{
+		{
+			Role: llm.ChatMessageTypeHuman,
+			Parts: []llm.ContentPart{
+				llm.TextPart("Describe"),
+				llm.TextPart("these images"),
+				llm.ImageURLPart(srv.URL + "/image.png"),
+				llm.ImageURLPart("data:image/png;base64,ZGVjb2RlZA=="),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := messages[0].Content, "Describe these images"; got != want {
+		t.Fatalf("messages[0].Content = %q, want %q", got, want)
+	}
+
+	if got, want := string(messages[0].Images[0]), "fetched"; got != want {
+		t.Fatalf("messages[0].Images[0] = %q, want %q", got, want)
+	}
+
+	if got, want := string(messages[0].Images[1]), "decoded"; got != want {
+		t.Fatalf("messages[0].Images[1] = %q, want %q", got, want)
+	}
+
+	_, err = p.makeOllamaMessages(ctx, []llm.Message{
+		{
+			Role:  llm.ChatMessageTypeHuman,
+			Parts: []llm.ContentPart{llm.ImageURLPart("data:image/png;base64,dG9vIGxhcmdlIGltYWdl")},
+		},
+	})
+	if !errors.Is(err, ErrImageTooLarge) {
+		t.Fatalf("expected ErrImageTooLarge, got %v", err)
+	}
+}
+
+func TestMakeOllamaMessagesRejectsUnsupportedParts(t *testing.T) {
+	p, err := New(WithServerURL("http://localhost:11434"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, part := range []llm.ContentPart{
+		llm.AudioPart("wav", []byte("RIFF")),
+		llm.FilePart("report.pdf", "application/pdf", []byte("%PDF")),
+		llm.BinaryPart("application/pdf", []byte("%PDF")),
+	} {
+		_, err := p.makeOllamaMessages(context.Background(), []llm.Message{
+			{Role: llm.ChatMessageTypeHuman, Parts: []llm.ContentPart{part}},
+		})
+
+		if !errors.Is(err, llm.ErrUnsupportedContentPart) {
+			t.Fatalf("err = %v, want %v", err, llm.ErrUnsupportedContentPart)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	p, err := llm.Open("ollama://example.com:1234/llama3?keep_alive=5m&num_ctx=4096")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	o, ok := p.(*Provider)
+	if !ok {
+		t.Fatalf("p = %T, want *Provider", p)
+	}
+
+	if got, want := o.ollamaServerURL.String(), "http://example.com:1234"; got != want {
+		t.Fatalf("server URL = %q, want %q", got, want)
+	}
+
+	if got, want := o.model, "llama3"; got != want {
+		t.Fatalf("model = %q, want %q", got, want)
+	}
+
+	if got, want := o.keepAlive, "5m"; got != want {
+		t.Fatalf("keepAlive = %q, want %q", got, want)
+	}
+
+	if got, want := o.ollamaOptions.NumCtx, 4096; got != want {
+		t.Fatalf("NumCtx = %d, want %d", got, want)
+	}
+}
+
+func TestProviderCreateEmbedding(t *testing.T) {
+	var (
+		requests int
+		path     string
+		req      map[string]any
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		path = r.URL.Path
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		w.Write([]byte(`{"model":"nomic-embed-text","embeddings":[[0.1,0.2],[0.3,0.4]]}`))
+	}))
+	defer srv.Close()
+
+	p, err := New(WithServerURL(srv.URL), WithModel("llama3"),
+		WithEmbeddingModel("nomic-embed-text"),
+		WithEmbeddingTruncate(false),
+		WithEmbeddingDimensions(2),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	embeddings, err := p.CreateEmbedding(context.Background(), []string{"hello", "world"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := path, "/api/embed"; got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+
+	for key, want := range map[string]any{
+		"model":      "nomic-embed-text",
+		"truncate":   false,
+		"dimensions": float64(2),
+	} {
+		if got := req[key]; got != want {
+			t.Fatalf("req[%q] = %v, want %v", key, got, want)
+		}
+	}
+
+	if got, want := req["input"], []any{"hello", "world"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf(`req["input"] = %v, want %v`, got, want)
+	}
+
+	want := [][]float32{{0.1, 0.2}, {0.3, 0.4}}
+
+	if !reflect.DeepEqual(embeddings, want) {
+		t.Fatalf("embeddings = %v, want %v", embeddings, want)
+	}
+
+	embeddings, err = p.CreateEmbedding(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if embeddings == nil || len(embeddings) != 0 {
+		t.Fatalf("embeddings = %#v, want empty", embeddings)
+	}
+
+	if got, want := requests, 1; got != want {
+		t.Fatalf("requests = %d, want %d", got, want)
+	}
+}
+
+func TestProviderGenerateContentWithTools(t *testing.T) {
+	tools := []llm.Tool{{
+		Type: "function",
+		Function: &llm.FunctionDefinition{
+			Name:        "getWeather",
+			Description: "Get the weather for a location",
+			Parameters: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"location": map[string]any{"type": "string"}},
+			},
+		},
+	}}
+
+	for _, stream := range []bool{false, true} {
+		var req struct {
+			Stream bool `json:"stream"`
+			Tools  []struct {
+				Type     string `json:"type"`
+				Function struct {
+					Name        string         `json:"name"`
+					Description string         `json:"description"`
+					Parameters  map[string]any `json:"parameters"`
+				} `json:"function"`
+			} `json:"tools"`
+		}
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if req.Stream {
+				w.Write([]byte(`{"message":{"role":"assistant","content":""},"done":false}` + "\n"))
+				w.Write([]byte(`{"message":{"role":"assistant","content":"","tool_calls":[` +
+					`{"function":{"name":"getWeather","arguments":{"location":"Boston"}}}]},"done":false}` + "\n"))
+				w.Write([]byte(`{"message":{"role":"assistant","content":""},"done":true,"done_reason":"stop"}` + "\n"))
+
+				return
+			}
+
+			w.Write([]byte(`{"message":{"role":"assistant","content":"","tool_calls":[` +
+				`{"id":"call_1","function":{"name":"getWeather","arguments":{"location":"Boston"}}}]},` +
+				`"done":true,"done_reason":"stop"}` + "\n"))
+		}))
+
+		p, err := New(WithServerURL(srv.URL), WithModel("llama3"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		options := []llm.ContentOption{llm.WithTools(tools)}
+
+		var chunks []string
+
+		if stream {
+			options = append(options, llm.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+				chunks = append(chunks, string(chunk))
+
+				return nil
+			}))
+		}
+
+		resp, err := llm.Content(context.Background(), p, "What is the weather in Boston?", options...)
+
+		srv.Close()
+
+		if err != nil {
+			t.Fatalf("stream=%v: unexpected error: %v", stream, err)
+		}
+
+		if got, want := req.Stream, stream; got != want {
+			t.Fatalf("req.Stream = %v, want %v", got, want)
+		}
+
+		if got, want := len(req.Tools), 1; got != want {
+			t.Fatalf("len(req.Tools) = %d, want %d", got, want)
+		}
+
+		if got, want := req.Tools[0].Function.Name, "getWeather"; got != want {
+			t.Fatalf("req.Tools[0].Function.Name = %q, want %q", got, want)
+		}
+
+		if got, want := req.Tools[0].Function.Description, "Get the weather for a location"; got != want {
+			t.Fatalf("req.Tools[0].Function.Description = %q, want %q", got, want)
+		}
+
+		if got, want := req.Tools[0].Function.Parameters["type"], "object"; got != want {
+			t.Fatalf(`req.Tools[0].Function.Parameters["type"] = %v, want %v`, got, want)
+		}
+
+		toolCalls := resp.Choices[0].ToolCalls
+
+		if got, want := len(toolCalls), 1; got != want {
+			t.Fatalf("stream=%v: len(toolCalls) = %d, want %d", stream, got, want)
+		}
+
+		if got, want := toolCalls[0].FunctionCall.Name, "getWeather"; got != want {
+			t.Fatalf("toolCalls[0].FunctionCall.Name = %q, want %q", got, want)
+		}
+
+		if got, want := toolCalls[0].FunctionCall.Arguments, `{"location":"Boston"}`; got != want {
+			t.Fatalf("toolCalls[0].FunctionCall.Arguments = %q, want %q", got, want)
+		}
+
+		if toolCalls[0].ID == "" || (!stream && toolCalls[0].ID != "call_1") || toolCalls[0].Type != "function" {
+			t.Fatalf("toolCalls[0] = %#v, want an ID and type function", toolCalls[0])
+		}
+
+		if got, want := resp.Choices[0].FuncCall, toolCalls[0].FunctionCall; got != want {
+			t.Fatalf("FuncCall = %#v, want %#v", got, want)
+		}
+
+		if stream && !slices.ContainsFunc(chunks, func(chunk string) bool {
+			return strings.Contains(chunk, `"getWeather"`)
+		}) {
+			t.Fatalf("chunks = %q, want a chunk with the tool calls", chunks)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package ollama
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+)
+
+const hostEnvVarName = "OLLAMA_HOST"
+
+func init() {
+	llm.RegisterProvider("ollama", func(dsn *llm.DSN, env llm.Env) (llm.Provider, error) {
+		return New(append(dsnOptions(dsn, env), WithModel(dsn.Model))...)
+	})
+
+	llm.RegisterEmbedder("ollama", func(dsn *llm.DSN, env llm.Env) (llm.EmbedderClient, error) {
+		return New(append(dsnOptions(dsn, env), WithEmbeddingModel(dsn.Model))...)
+	})
+}
+
+// dsnOptions returns the options for a DSN such as "ollama://localhost:11434/llama3?keep_alive=5m".
+// The host is read from the OLLAMA_HOST environment variable if not part of the DSN.
+// Supported params are keep_alive, format, system, template, num_ctx, truncate and dimensions.
+func dsnOptions(dsn *llm.DSN, env llm.Env) []Option {
+	params := dsn.Env()
+
+	var opts []Option
+
+	if host := cmp.Or(dsn.Host, env.Get(hostEnvVarName)); strings.Contains(host, "://") {
+		opts = append(opts, WithServerURL(host))
+	} else {
+		opts = append(opts, WithHost(host))
+	}
+
+	if keepAlive := params.Get("keep_alive"); keepAlive != "" {
+		opts = append(opts, WithKeepAlive(keepAlive))
+	}
+
+	if format := params.Get("format"); format != "" {
+		opts = append(opts, WithFormat(format))
+	}
+
+	if system := params.Get("system"); system != "" {
+		opts = append(opts, WithSystemPrompt(system))
+	}
+
+	if template := params.Get("template"); template != "" {
+		opts = append(opts, WithCustomTemplate(template))
+	}
+
+	if numCtx := params.Int("num_ctx", 0); numCtx > 0 {
+		opts = append(opts, WithRunnerNumCtx(numCtx))
+	}
+
+	if params.Get("truncate") != "" {
+		opts = append(opts, WithEmbeddingTruncate(params.Bool("truncate", true)))
+	}
+
+	if dimensions := params.Int("dimensions", 0); dimensions > 0 {
+		opts = append(opts, WithEmbeddingDimensions(dimensions))
+	}
+
+	return opts
+}
@@ -11,8 +11,10 @@ import (
 )
 
 const (
-	defaultOllamaHost = "localhost"
-	defaultOllamaPort = "11434"
+	defaultOllamaHost    = "localhost"
+	defaultOllamaPort    = "11434"
+	defaultTextSeparator = "\n"
+	defaultMaxImageSize  = 20 << 20
 )
 
 type Options struct {
@@ -20,11 +22,16 @@ type Options struct {
 	ollamaServerURL     *url.URL
 	httpClient          *http.Client
 	model               string
+	embeddingModel      string
+	embeddingTruncate   *bool
+	embeddingDimensions int
 	ollamaOptions       ollama.Options
 	customModelTemplate string
 	system              string
 	format              string
 	keepAlive           string
+	textSeparator       string
+	maxImageSize        int64
 }
 
 type Option func(*Options) error
@@ -71,6 +78,35 @@ func WithModel(model string) Option {
 	}
 }
 
+// WithEmbeddingModel Set the model to use for embeddings. Defaults to the model set by WithModel.
+func WithEmbeddingModel(model string) Option {
+	return func(opts *Options) error {
+		opts.embeddingModel = model
+
+		return nil
+	}
+}
+
+// WithEmbeddingTruncate controls if inputs exceeding the context length of the embedding
+// model are truncated (the Ollama default), or if an error is returned instead.
+func WithEmbeddingTruncate(truncate bool) Option {
+	return func(opts *Options) error {
+		opts.embeddingTruncate = &truncate
+
+		return nil
+	}
+}
+
+// WithEmbeddingDimensions Set the number of dimensions of the resulting embeddings.
+// Only supported by some embedding models.
+func WithEmbeddingDimensions(dimensions int) Option {
+	return func(opts *Options) error {
+		opts.embeddingDimensions = dimensions
+
+		return nil
+	}
+}
+
 // WithFormat Sets the Ollama output format (currently Ollama only supports "json").
 func WithFormat(format string) Option {
 	return func(opts *Options) error {
@@ -99,6 +135,9 @@ func WithKeepAlive(keepAlive string) Option {
 // WithCustomTemplate is not set and the ollama model use
 // .System in its model template OR if WithCustomTemplate
 // is set using {{.System}}.
+//
+// GenerateContent sends the system prompt as a system message,
+// unless the messages already contain a system message.
 func WithSystemPrompt(p string) Option {
 	return func(opts *Options) error {
 		opts.system = p
@@ -108,6 +147,9 @@ func WithSystemPrompt(p string) Option {
 }
 
 // WithCustomTemplate To override the templating done on Ollama model side.
+//
+// Only used by Generate, since the Ollama chat endpoint
+// used by GenerateContent always uses the model template.
 func WithCustomTemplate(template string) Option {
 	return func(opts *Options) error {
 		opts.customModelTemplate = template
@@ -116,6 +158,24 @@ func WithCustomTemplate(template string) Option {
 	}
 }
 
+// WithTextSeparator Set the separator used to join multiple Text parts in a message (default: "\n").
+func WithTextSeparator(separator string) Option {
+	return func(opts *Options) error {
+		opts.textSeparator = separator
+
+		return nil
+	}
+}
+
+// WithMaxImageSize Set the maximum size in bytes of images fetched for ImageURL parts (default: 20 MiB).
+func WithMaxImageSize(size int64) Option {
+	return func(opts *Options) error {
+		opts.maxImageSize = size
+
+		return nil
+	}
+}
+
 // WithServerURL Set the URL of the ollama instance to use.
 func WithServerURL(rawURL string) Option {
 	return func(opts *Options) error {
@@ -0,0 +1,131 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/providers/openai/internal/openai"
+)
+
+var (
+	_ llm.Transcriber       = (*Provider)(nil)
+	_ llm.SpeechSynthesizer = (*Provider)(nil)
+)
+
+// Transcribe reads all audio from r and uploads it to the transcriptions API.
+// The audio format is sniffed from the data, or taken from the filename set
+// by llm.WithAudioFilename. Segments are returned by the whisper models.
+func (o *Provider) Transcribe(ctx context.Context, r io.Reader, options ...llm.TranscriptionOption) (*llm.Transcription, error) {
+	opts := llm.TranscriptionOptions{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	if o.hooks != nil {
+		o.hooks.ProviderStart(ctx, []string{opts.Prompt})
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		if o.hooks != nil {
+			o.hooks.ProviderError(ctx, err)
+		}
+
+		return nil, fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	contentType := llm.DetectMIMEType(data, opts.Filename)
+
+	filename := opts.Filename
+
+	if filename == "" {
+		filename = "audio." + audioExtension(contentType)
+	}
+
+	resp, err := o.client.CreateTranscription(ctx, &openai.TranscriptionRequest{
+		Model:       opts.Model,
+		Audio:       data,
+		Filename:    filename,
+		ContentType: contentType,
+		Language:    opts.Language,
+		Prompt:      opts.Prompt,
+		Temperature: opts.Temperature,
+	})
+	if err != nil {
+		if o.hooks != nil {
+			o.hooks.ProviderError(ctx, err)
+		}
+
+		return nil, fmt.Errorf("failed to create openai transcription: %w", err)
+	}
+
+	transcription := &llm.Transcription{
+		Text:     resp.Text,
+		Language: resp.Language,
+		Duration: seconds(resp.Duration),
+	}
+
+	for _, s := range resp.Segments {
+		transcription.Segments = append(transcription.Segments, llm.TranscriptionSegment{
+			Start: seconds(s.Start),
+			End:   seconds(s.End),
+			Text:  s.Text,
+		})
+	}
+
+	return transcription, nil
+}
+
+// SynthesizeSpeech streams the audio generated by the speech API.
+// The returned io.ReadCloser must be closed by the caller.
+func (o *Provider) SynthesizeSpeech(ctx context.Context, text string, options ...llm.SpeechOption) (io.ReadCloser, error) {
+	opts := llm.SpeechOptions{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	if o.hooks != nil {
+		o.hooks.ProviderStart(ctx, []string{text})
+	}
+
+	rc, err := o.client.CreateSpeech(ctx, &openai.SpeechRequest{
+		Model:          opts.Model,
+		Input:          text,
+		Voice:          opts.Voice,
+		Instructions:   opts.Instructions,
+		ResponseFormat: opts.Format,
+		Speed:          opts.Speed,
+	})
+	if err != nil {
+		if o.hooks != nil {
+			o.hooks.ProviderError(ctx, err)
+		}
+
+		return nil, fmt.Errorf("failed to create openai speech: %w", err)
+	}
+
+	return rc, nil
+}
+
+// audioExtension returns the file extension for the content type. MP3 is used if
+// the content type is not recognized, since MP3 files without ID3 tags can not be sniffed.
+func audioExtension(contentType string) string {
+	switch {
+	case strings.HasPrefix(contentType, "audio/"):
+		return llm.AudioFormat(contentType)
+	case strings.HasPrefix(contentType, "video/"):
+		return strings.TrimPrefix(contentType, "video/")
+	default:
+		return "mp3"
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,231 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+func TestProviderTranscribe(t *testing.T) {
+	audio := []byte("RIFF\x24\x00\x00\x00WAVEfmt ")
+
+	var (
+		path   string
+		fields = map[string]string{}
+		file   []byte
+		name   string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("unexpected error: %v", err)
+
+			return
+		}
+
+		for key := range r.MultipartForm.Value {
+			fields[key] = r.FormValue(key)
+		}
+
+		f, h, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+
+			return
+		}
+		defer f.Close()
+
+		name = h.Filename
+		file, _ = io.ReadAll(f)
+
+		w.Write([]byte(`{"text":"hello","language":"english","duration":1.5,"segments":[{"id":0,"start":0,"end":1.5,"text":"hello"}]}`))
+	}))
+	defer srv.Close()
+
+	var prompts []string
+
+	p, err := New(WithToken("test"), WithBaseURL(srv.URL), WithHooks(mock.Hooks{
+		ProviderStartFunc: func(_ context.Context, p []string) {
+			prompts = p
+		},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	transcription, err := p.Transcribe(context.Background(), bytes.NewReader(audio),
+		llm.WithTranscriptionModel("whisper-1"),
+		llm.WithTranscriptionLanguage("en"),
+		llm.WithTranscriptionPrompt("a greeting"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := path, "/audio/transcriptions"; got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+
+	for key, want := range map[string]string{
+		"model":           "whisper-1",
+		"language":        "en",
+		"prompt":          "a greeting",
+		"response_format": "verbose_json",
+	} {
+		if got := fields[key]; got != want {
+			t.Fatalf("fields[%q] = %q, want %q", key, got, want)
+		}
+	}
+
+	if got, want := name, "audio.wav"; got != want {
+		t.Fatalf("name = %q, want %q", got, want)
+	}
+
+	if !bytes.Equal(file, audio) {
+		t.Fatalf("file = %q, want %q", file, audio)
+	}
+
+	if got, want := len(prompts), 1; got != want || prompts[0] != "a greeting" {
+		t.Fatalf("prompts = %q, want [%q]", prompts, "a greeting")
+	}
+
+	if got, want := transcription.Text, "hello"; got != want {
+		t.Fatalf("transcription.Text = %q, want %q", got, want)
+	}
+
+	if got, want := transcription.Duration, 1500*time.Millisecond; got != want {
+		t.Fatalf("transcription.Duration = %v, want %v", got, want)
+	}
+
+	if got, want := len(transcription.Segments), 1; got != want {
+		t.Fatalf("len(transcription.Segments) = %d, want %d", got, want)
+	}
+}
+
+func TestProviderSynthesizeSpeech(t *testing.T) {
+	var (
+		path  string
+		req   map[string]any
+		first = make(chan struct{})
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write([]byte("chunk1"))
+		w.(http.Flusher).Flush()
+
+		// The second chunk is only written once the first has been read,
+		// which would time out if the response was buffered.
+		select {
+		case <-first:
+		case <-time.After(5 * time.Second):
+			t.Errorf("first chunk was not read before the response ended")
+		}
+
+		w.Write([]byte("chunk2"))
+	}))
+	defer srv.Close()
+
+	client := &closeRecorder{}
+
+	p, err := New(WithToken("test"), WithBaseURL(srv.URL), WithHTTPClient(client))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rc, err := p.SynthesizeSpeech(context.Background(), "hello",
+		llm.WithSpeechModel("tts-1"),
+		llm.WithVoice("nova"),
+		llm.WithSpeechFormat("mp3"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := make([]byte, len("chunk1"))
+
+	if _, err := io.ReadFull(rc, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	close(first)
+
+	rest, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := string(buf)+string(rest), "chunk1chunk2"; got != want {
+		t.Fatalf("audio = %q, want %q", got, want)
+	}
+
+	if client.closed {
+		t.Fatalf("body closed before rc.Close")
+	}
+
+	if err := rc.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !client.closed {
+		t.Fatalf("body not closed by rc.Close")
+	}
+
+	if got, want := path, "/audio/speech"; got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+
+	for key, want := range map[string]any{
+		"model":           "tts-1",
+		"input":           "hello",
+		"voice":           "nova",
+		"response_format": "mp3",
+	} {
+		if got := req[key]; got != want {
+			t.Fatalf("req[%q] = %v, want %v", key, got, want)
+		}
+	}
+}
+
+// closeRecorder sends requests using the default client, and records if the response body is closed.
+type closeRecorder struct {
+	closed bool
+}
+
+func (c *closeRecorder) Do(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = recordingBody{resp.Body, c}
+
+	return resp, nil
+}
+
+type recordingBody struct {
+	io.ReadCloser
+	c *closeRecorder
+}
+
+func (b recordingBody) Close() error {
+	b.c.closed = true
+
+	return b.ReadCloser.Close()
+}
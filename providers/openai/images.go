@@ -0,0 +1,98 @@
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/providers/openai/internal/openai"
+)
+
+var (
+	_ llm.ImageGenerator = (*Provider)(nil)
+	_ llm.ImageEditor    = (*Provider)(nil)
+)
+
+// GenerateImages generates images from the prompt using the images API.
+func (o *Provider) GenerateImages(ctx context.Context, prompt string, options ...llm.ImageOption) ([]llm.GeneratedImage, error) {
+	req := makeImageRequest(prompt, nil, options)
+
+	return o.images(ctx, []string{prompt}, o.client.CreateImage, req)
+}
+
+// EditImage edits the image as described by the prompt, using the mask set by llm.WithImageMask if any.
+func (o *Provider) EditImage(ctx context.Context, image []byte, prompt string, options ...llm.ImageOption) ([]llm.GeneratedImage, error) {
+	req := makeImageRequest(prompt, image, options)
+
+	return o.images(ctx, []string{prompt}, o.client.CreateImageEdit, req)
+}
+
+// CreateImageVariations creates variations of the image.
+func (o *Provider) CreateImageVariations(ctx context.Context, image []byte, options ...llm.ImageOption) ([]llm.GeneratedImage, error) {
+	req := makeImageRequest("", image, options)
+
+	return o.images(ctx, nil, o.client.CreateImageVariation, req)
+}
+
+func (o *Provider) images(ctx context.Context, prompts []string,
+	create func(context.Context, *openai.ImageRequest) (*openai.ImageResponse, error),
+	req *openai.ImageRequest,
+) ([]llm.GeneratedImage, error) {
+	if o.hooks != nil {
+		o.hooks.ProviderStart(ctx, prompts)
+	}
+
+	resp, err := create(ctx, req)
+	if err != nil {
+		if o.hooks != nil {
+			o.hooks.ProviderError(ctx, err)
+		}
+
+		return nil, fmt.Errorf("failed to create openai images: %w", err)
+	}
+
+	images := make([]llm.GeneratedImage, len(resp.Data))
+
+	for i, d := range resp.Data {
+		images[i] = llm.GeneratedImage{
+			URL:           d.URL,
+			RevisedPrompt: d.RevisedPrompt,
+		}
+
+		if d.B64JSON == "" {
+			continue
+		}
+
+		data, err := base64.StdEncoding.DecodeString(d.B64JSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode openai image: %w", err)
+		}
+
+		images[i].Data = data
+		images[i].MIMEType = http.DetectContentType(data)
+	}
+
+	return images, nil
+}
+
+func makeImageRequest(prompt string, image []byte, options []llm.ImageOption) *openai.ImageRequest {
+	opts := llm.ImageOptions{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	return &openai.ImageRequest{
+		Model:          opts.Model,
+		Prompt:         prompt,
+		N:              opts.N,
+		Size:           opts.Size,
+		Quality:        opts.Quality,
+		Style:          opts.Style,
+		ResponseFormat: opts.ResponseFormat,
+		Image:          image,
+		Mask:           opts.Mask,
+	}
+}
@@ -0,0 +1,240 @@
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+)
+
+func TestProviderGenerateImages(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	var (
+		path string
+		req  map[string]any
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"created": 1,
+			"data": []map[string]any{
+				{"url": "https://example.com/cat.png", "revised_prompt": "a fluffy cat"},
+				{"b64_json": base64.StdEncoding.EncodeToString(png)},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	p, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	images, err := p.GenerateImages(context.Background(), "a cat",
+		llm.WithImageModel("dall-e-3"),
+		llm.WithImageSize("1024x1024"),
+		llm.WithImageCount(2),
+		llm.WithImageResponseFormat("b64_json"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := path, "/images/generations"; got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+
+	for key, want := range map[string]any{
+		"model":           "dall-e-3",
+		"prompt":          "a cat",
+		"size":            "1024x1024",
+		"n":               float64(2),
+		"response_format": "b64_json",
+	} {
+		if got := req[key]; got != want {
+			t.Fatalf("req[%q] = %v, want %v", key, got, want)
+		}
+	}
+
+	if got, want := len(images), 2; got != want {
+		t.Fatalf("len(images) = %d, want %d", got, want)
+	}
+
+	if got, want := images[0].URL, "https://example.com/cat.png"; got != want {
+		t.Fatalf("images[0].URL = %q, want %q", got, want)
+	}
+
+	if got, want := images[0].RevisedPrompt, "a fluffy cat"; got != want {
+		t.Fatalf("images[0].RevisedPrompt = %q, want %q", got, want)
+	}
+
+	if got, want := string(images[1].Data), string(png); got != want {
+		t.Fatalf("images[1].Data = %q, want %q", got, want)
+	}
+
+	if got, want := images[1].MIMEType, "image/png"; got != want {
+		t.Fatalf("images[1].MIMEType = %q, want %q", got, want)
+	}
+}
+
+type formFile struct {
+	filename    string
+	contentType string
+	data        string
+}
+
+type imageForm struct {
+	path   string
+	fields map[string]string
+	files  map[string]formFile
+}
+
+// newImageFormServer returns a server that records the multipart form of the last request.
+func newImageFormServer(t *testing.T) (*httptest.Server, *imageForm) {
+	t.Helper()
+
+	form := &imageForm{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		form.path = r.URL.Path
+		form.fields = map[string]string{}
+		form.files = map[string]formFile{}
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("unexpected error: %v", err)
+
+			return
+		}
+
+		for key := range r.MultipartForm.Value {
+			form.fields[key] = r.FormValue(key)
+		}
+
+		for key, headers := range r.MultipartForm.File {
+			f, err := headers[0].Open()
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+
+				return
+			}
+
+			data, _ := io.ReadAll(f)
+			f.Close()
+
+			form.files[key] = formFile{
+				filename:    headers[0].Filename,
+				contentType: headers[0].Header.Get("Content-Type"),
+				data:        string(data),
+			}
+		}
+
+		w.Write([]byte(`{"created":1,"data":[{"url":"https://example.com/edited.png"}]}`))
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, form
+}
+
+func TestProviderEditImage(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+	mask := "\x89PNG\r\n\x1a\n\x00\x00\x00\rMASK"
+
+	srv, form := newImageFormServer(t)
+
+	p, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	images, err := p.EditImage(context.Background(), []byte(png), "add a hat",
+		llm.WithImageModel("gpt-image-1"),
+		llm.WithImageSize("512x512"),
+		llm.WithImageCount(2),
+		llm.WithImageQuality("high"),
+		llm.WithImageResponseFormat("url"),
+		llm.WithImageMask([]byte(mask)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := form.path, "/images/edits"; got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+
+	want := map[string]string{
+		"model":           "gpt-image-1",
+		"prompt":          "add a hat",
+		"size":            "512x512",
+		"n":               "2",
+		"quality":         "high",
+		"response_format": "url",
+	}
+
+	if !reflect.DeepEqual(form.fields, want) {
+		t.Fatalf("fields = %q, want %q", form.fields, want)
+	}
+
+	wantFiles := map[string]formFile{
+		"image": {filename: "image.png", contentType: "image/png", data: png},
+		"mask":  {filename: "mask.png", contentType: "image/png", data: mask},
+	}
+
+	if !reflect.DeepEqual(form.files, wantFiles) {
+		t.Fatalf("files = %+v, want %+v", form.files, wantFiles)
+	}
+
+	if got, want := images[0].URL, "https://example.com/edited.png"; got != want {
+		t.Fatalf("images[0].URL = %q, want %q", got, want)
+	}
+}
+
+func TestProviderCreateImageVariations(t *testing.T) {
+	jpeg := "\xff\xd8\xff\xe0\x00\x10JFIF\x00"
+
+	srv, form := newImageFormServer(t)
+
+	p, err := New(WithToken("test"), WithBaseURL(srv.URL), WithImageModel("dall-e-2"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	images, err := p.CreateImageVariations(context.Background(), []byte(jpeg), llm.WithImageCount(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := form.path, "/images/variations"; got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+
+	if want := map[string]string{"model": "dall-e-2", "n": "3"}; !reflect.DeepEqual(form.fields, want) {
+		t.Fatalf("fields = %q, want %q", form.fields, want)
+	}
+
+	wantFiles := map[string]formFile{
+		"image": {filename: "image.jpg", contentType: "image/jpeg", data: jpeg},
+	}
+
+	if !reflect.DeepEqual(form.files, wantFiles) {
+		t.Fatalf("files = %+v, want %+v", form.files, wantFiles)
+	}
+
+	if got, want := len(images), 1; got != want {
+		t.Fatalf("len(images) = %d, want %d", got, want)
+	}
+}
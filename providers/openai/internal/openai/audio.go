@@ -0,0 +1,121 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultTranscriptionModel = "whisper-1"
+	defaultSpeechModel        = "gpt-4o-mini-tts"
+	defaultSpeechVoice        = "alloy"
+)
+
+// TranscriptionRequest is a request to transcribe audio into text.
+type TranscriptionRequest struct {
+	Model       string
+	Audio       []byte
+	Filename    string
+	ContentType string
+	Language    string
+	Prompt      string
+	Temperature float64
+}
+
+// TranscriptionSegment is a segment of transcribed audio, with start and end in seconds.
+type TranscriptionSegment struct {
+	ID    int     `json:"id"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+// TranscriptionResponse is a response to a transcription request.
+// Language, duration and segments are only returned by models supporting verbose_json.
+type TranscriptionResponse struct {
+	Text     string                 `json:"text"`
+	Language string                 `json:"language,omitempty"`
+	Duration float64                `json:"duration,omitempty"`
+	Segments []TranscriptionSegment `json:"segments,omitempty"`
+}
+
+// CreateTranscription uploads the audio and transcribes it into text.
+func (c *Client) CreateTranscription(ctx context.Context, r *TranscriptionRequest) (*TranscriptionResponse, error) {
+	if r.Model == "" {
+		r.Model = c.TranscriptionModel
+	}
+
+	if r.Model == "" {
+		r.Model = defaultTranscriptionModel
+	}
+
+	fields := map[string]string{
+		"model":           r.Model,
+		"language":        r.Language,
+		"prompt":          r.Prompt,
+		"response_format": "json",
+	}
+
+	// Only the whisper models return segments with timestamps.
+	if strings.HasPrefix(r.Model, "whisper") {
+		fields["response_format"] = "verbose_json"
+		fields["timestamp_granularities[]"] = "segment"
+	}
+
+	if r.Temperature != 0 {
+		fields["temperature"] = strconv.FormatFloat(r.Temperature, 'f', -1, 64)
+	}
+
+	req, err := c.multipartRequest(ctx, "/audio/transcriptions", r.Model, fields, formFile{
+		field:       "file",
+		filename:    r.Filename,
+		contentType: r.ContentType,
+		data:        r.Audio,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response TranscriptionResponse
+
+	if err := c.do(req, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// SpeechRequest is a request to generate audio from text.
+type SpeechRequest struct {
+	Model          string  `json:"model"`
+	Input          string  `json:"input"`
+	Voice          string  `json:"voice"`
+	Instructions   string  `json:"instructions,omitempty"`
+	ResponseFormat string  `json:"response_format,omitempty"`
+	Speed          float64 `json:"speed,omitempty"`
+}
+
+// CreateSpeech generates audio from the input text. The audio
+// is streamed from the returned reader, which must be closed.
+func (c *Client) CreateSpeech(ctx context.Context, r *SpeechRequest) (io.ReadCloser, error) {
+	if r.Model == "" {
+		r.Model = c.SpeechModel
+	}
+
+	if r.Model == "" {
+		r.Model = defaultSpeechModel
+	}
+
+	if r.Voice == "" {
+		r.Voice = defaultSpeechVoice
+	}
+
+	req, err := c.jsonRequest(ctx, "/audio/speech", r.Model, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doStream(req)
+}
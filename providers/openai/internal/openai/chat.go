@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -66,6 +67,12 @@ type ChatRequest struct {
 
 	// Metadata allows you to specify additional information that will be passed to the model.
 	Metadata map[string]any `json:"metadata,omitempty"`
+
+	// Modalities are the output types the model should generate, e.g. ["text", "audio"].
+	Modalities []string `json:"modalities,omitempty"`
+
+	// Audio configures the audio output, required when Modalities contains "audio".
+	Audio *AudioOutput `json:"audio,omitempty"`
 }
 
 // ToolType is the type of a tool.
@@ -146,6 +153,10 @@ type ChatMessage struct {
 
 	// This field is only used with the deepseek-reasoner model and represents the reasoning contents of the assistant message before the final answer.
 	ReasoningContent string `json:"reasoning_content,omitempty"`
+
+	// Audio is the audio generated by the model, or a reference
+	// to previously generated audio in an assistant message.
+	Audio *Audio `json:"audio,omitempty"`
 }
 
 func (m ChatMessage) MarshalJSON() ([]byte, error) {
@@ -158,41 +169,53 @@ func (m ChatMessage) MarshalJSON() ([]byte, error) {
 		m.MultiContent = nil
 	}
 
+	msg := chatMessageJSON{
+		Role:             m.Role,
+		Content:          m.Content,
+		Name:             m.Name,
+		ToolCalls:        m.ToolCalls,
+		ToolCallID:       m.ToolCallID,
+		ReasoningContent: m.ReasoningContent,
+		Audio:            m.Audio,
+	}
+
 	if len(m.MultiContent) > 0 {
-		msg := struct {
-			Role         string            `json:"role"`
-			Content      string            `json:"-"`
-			MultiContent []llm.ContentPart `json:"content,omitempty"`
-			Name         string            `json:"name,omitempty"`
-			ToolCalls    []ToolCall        `json:"tool_calls,omitempty"`
+		parts, audio, err := makeContentParts(m.MultiContent)
+		if err != nil {
+			return nil, err
+		}
 
-			// ToolCallID is the ID of the tool call this message is for.
-			// Only present in tool messages.
-			ToolCallID string `json:"tool_call_id,omitempty"`
+		msg.Content = nil
 
-			// This field is only used with the deepseek-reasoner model and represents the reasoning contents of the assistant message before the final answer.
-			ReasoningContent string `json:"reasoning_content,omitempty"`
-		}(m)
+		if len(parts) > 0 {
+			msg.Content = parts
+		}
 
-		return json.Marshal(msg)
+		if audio != nil {
+			msg.Audio = audio
+		}
 	}
 
-	msg := struct {
-		Role         string            `json:"role"`
-		Content      string            `json:"content"`
-		MultiContent []llm.ContentPart `json:"-"`
-		Name         string            `json:"name,omitempty"`
-		ToolCalls    []ToolCall        `json:"tool_calls,omitempty"`
+	return json.Marshal(msg)
+}
 
-		// ToolCallID is the ID of the tool call this message is for.
-		// Only present in tool messages.
-		ToolCallID string `json:"tool_call_id,omitempty"`
+// chatMessageJSON is the JSON representation of a ChatMessage, where
+// the content is either a string or a list of content parts.
+type chatMessageJSON struct {
+	Role      string     `json:"role"`
+	Content   any        `json:"content"`
+	Name      string     `json:"name,omitempty"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
 
-		// This field is only used with the deepseek-reasoner model and represents the reasoning contents of the assistant message before the final answer.
-		ReasoningContent string `json:"reasoning_content,omitempty"`
-	}(m)
+	// ToolCallID is the ID of the tool call this message is for.
+	// Only present in tool messages.
+	ToolCallID string `json:"tool_call_id,omitempty"`
 
-	return json.Marshal(msg)
+	// This field is only used with the deepseek-reasoner model and represents the reasoning contents of the assistant message before the final answer.
+	ReasoningContent string `json:"reasoning_content,omitempty"`
+
+	// Audio refers to audio previously generated by the model.
+	Audio *Audio `json:"audio,omitempty"`
 }
 
 func isSingleTextContent(parts []llm.ContentPart) (string, bool) {
@@ -218,6 +241,9 @@ func (m *ChatMessage) UnmarshalJSON(data []byte) error {
 
 		// This field is only used with the deepseek-reasoner model and represents the reasoning contents of the assistant message before the final answer.
 		ReasoningContent string `json:"reasoning_content,omitempty"`
+
+		// Audio is the audio generated by the model, if requested.
+		Audio *Audio `json:"audio,omitempty"`
 	}{}
 
 	if err := json.Unmarshal(data, &msg); err != nil {
@@ -324,6 +350,8 @@ type StreamedChatResponsePayload struct {
 			ToolCalls []*ToolCall `json:"tool_calls,omitempty"`
 			// This field is only used with the deepseek-reasoner model and represents the reasoning contents of the assistant message before the final answer.
 			ReasoningContent string `json:"reasoning_content,omitempty"`
+			// Audio is a chunk of the audio generated by the model.
+			Audio *Audio `json:"audio,omitempty"`
 		} `json:"delta,omitempty"`
 		FinishReason FinishReason `json:"finish_reason,omitempty"`
 	} `json:"choices,omitempty"`
@@ -480,6 +508,15 @@ func combineStreamingChatResponse(
 		response.Choices[0].FinishReason = choice.FinishReason
 		response.Choices[0].Message.ReasoningContent = choice.Delta.ReasoningContent
 
+		if choice.Delta.Audio != nil {
+			audio, err := updateAudio(response.Choices[0].Message.Audio, choice.Delta.Audio)
+			if err != nil {
+				return nil, err
+			}
+
+			response.Choices[0].Message.Audio = audio
+		}
+
 		if len(choice.Delta.ToolCalls) > 0 {
 			chunk, response.Choices[0].Message.ToolCalls = updateToolCalls(response.Choices[0].Message.ToolCalls,
 				choice.Delta.ToolCalls)
@@ -492,9 +529,38 @@ func combineStreamingChatResponse(
 		}
 	}
 
+	if audio := response.Choices[0].Message.Audio; audio != nil {
+		audio.Data = base64.StdEncoding.EncodeToString(audio.data)
+	}
+
 	return &response, nil
 }
 
+// updateAudio appends the decoded data and the transcript of the delta to the audio.
+func updateAudio(audio *Audio, delta *Audio) (*Audio, error) {
+	if audio == nil {
+		audio = &Audio{}
+	}
+
+	if delta.ID != "" {
+		audio.ID = delta.ID
+	}
+
+	if delta.ExpiresAt != 0 {
+		audio.ExpiresAt = delta.ExpiresAt
+	}
+
+	data, err := base64.StdEncoding.DecodeString(delta.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding streaming audio: %w", err)
+	}
+
+	audio.data = append(audio.data, data...)
+	audio.Transcript += delta.Transcript
+
+	return audio, nil
+}
+
 func updateToolCalls(tools []ToolCall, delta []*ToolCall) ([]byte, []ToolCall) {
 	if len(delta) == 0 {
 		return []byte{}, tools
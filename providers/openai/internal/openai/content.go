@@ -0,0 +1,134 @@
+package openai
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+)
+
+// ContentPart is a content part in the format used by the chat completions API.
+type ContentPart struct {
+	Type       string      `json:"type"`
+	Text       string      `json:"text,omitempty"`
+	ImageURL   *ImageURL   `json:"image_url,omitempty"`
+	InputAudio *InputAudio `json:"input_audio,omitempty"`
+	File       *File       `json:"file,omitempty"`
+}
+
+// ImageURL is an image in a content part, either an URL or a data URL.
+type ImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// InputAudio is base64 encoded audio in a content part.
+type InputAudio struct {
+	Data   string `json:"data"`
+	Format string `json:"format"`
+}
+
+// File is a file in a content part, either as a data URL or the ID of an uploaded file.
+type File struct {
+	Filename string `json:"filename,omitempty"`
+	FileData string `json:"file_data,omitempty"`
+	FileID   string `json:"file_id,omitempty"`
+}
+
+// Audio is audio generated by the model. Only the ID is
+// used when referring to the audio in an assistant message.
+type Audio struct {
+	ID         string `json:"id"`
+	Data       string `json:"data,omitempty"`
+	ExpiresAt  int64  `json:"expires_at,omitempty"`
+	Transcript string `json:"transcript,omitempty"`
+
+	// data is the decoded data of a streamed response.
+	data []byte
+}
+
+// AudioOutput configures the audio generated by the model.
+type AudioOutput struct {
+	Voice  string `json:"voice"`
+	Format string `json:"format"`
+}
+
+// makeContentParts converts the parts into the format used by the API.
+// Audio output parts are returned as a reference to the audio instead.
+func makeContentParts(parts []llm.ContentPart) ([]ContentPart, *Audio, error) {
+	var (
+		contentParts []ContentPart
+		audio        *Audio
+	)
+
+	for _, part := range parts {
+		switch p := part.(type) {
+		case llm.TextContent:
+			contentParts = append(contentParts, ContentPart{Type: "text", Text: p.Text})
+		case llm.ImageURLContent:
+			contentParts = append(contentParts, ContentPart{
+				Type:     "image_url",
+				ImageURL: &ImageURL{URL: p.URL, Detail: p.Detail},
+			})
+		case llm.BinaryContent:
+			contentParts = append(contentParts, makeBinaryContentPart(p))
+		case llm.AudioContent:
+			contentParts = append(contentParts, ContentPart{
+				Type: "input_audio",
+				InputAudio: &InputAudio{
+					Data:   base64.StdEncoding.EncodeToString(p.Data),
+					Format: p.Format,
+				},
+			})
+		case llm.FileContent:
+			file := &File{Filename: p.Filename, FileID: p.FileID}
+
+			if p.FileID == "" {
+				file.FileData = p.String()
+
+				if file.Filename == "" {
+					file.Filename = makeFilename(p.MIMEType)
+				}
+			}
+
+			contentParts = append(contentParts, ContentPart{Type: "file", File: file})
+		case llm.AudioOutputContent:
+			audio = &Audio{ID: p.ID}
+		default:
+			return nil, nil, fmt.Errorf("%w: %T", llm.ErrUnsupportedContentPart, part)
+		}
+	}
+
+	return contentParts, audio, nil
+}
+
+// makeBinaryContentPart sends binary content as an image,
+// as input audio or as a file depending on its MIME type.
+func makeBinaryContentPart(p llm.BinaryContent) ContentPart {
+	switch {
+	case strings.HasPrefix(p.MIMEType, "audio/"):
+		return ContentPart{
+			Type: "input_audio",
+			InputAudio: &InputAudio{
+				Data:   base64.StdEncoding.EncodeToString(p.Data),
+				Format: llm.AudioFormat(p.MIMEType),
+			},
+		}
+	case p.MIMEType == "" || strings.HasPrefix(p.MIMEType, "image/"):
+		return ContentPart{Type: "image_url", ImageURL: &ImageURL{URL: p.String()}}
+	default:
+		return ContentPart{Type: "file", File: &File{Filename: makeFilename(p.MIMEType), FileData: p.String()}}
+	}
+}
+
+// makeFilename returns a filename for file data sent without one,
+// since the API requires a filename along with the file data.
+func makeFilename(mimeType string) string {
+	if exts, err := mime.ExtensionsByType(mimeType); err == nil && len(exts) > 0 {
+		return "file" + exts[0]
+	}
+
+	return "file"
+}
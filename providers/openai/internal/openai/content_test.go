@@ -0,0 +1,115 @@
+package openai
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+)
+
+func TestMakeContentParts(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		part llm.ContentPart
+		want []ContentPart
+	}{
+		{
+			name: "text",
+			part: llm.TextContent{Text: "hello"},
+			want: []ContentPart{{Type: "text", Text: "hello"}},
+		},
+		{
+			name: "image url",
+			part: llm.ImageURLContent{URL: "https://example.com/cat.png", Detail: "low"},
+			want: []ContentPart{{Type: "image_url", ImageURL: &ImageURL{URL: "https://example.com/cat.png", Detail: "low"}}},
+		},
+		{
+			name: "binary image",
+			part: llm.BinaryContent{MIMEType: "image/png", Data: []byte("png")},
+			want: []ContentPart{{Type: "image_url", ImageURL: &ImageURL{URL: "data:image/png;base64,cG5n"}}},
+		},
+		{
+			name: "binary audio",
+			part: llm.BinaryContent{MIMEType: "audio/mpeg", Data: []byte("mp3")},
+			want: []ContentPart{{Type: "input_audio", InputAudio: &InputAudio{Data: "bXAz", Format: "mp3"}}},
+		},
+		{
+			name: "binary file",
+			part: llm.BinaryContent{MIMEType: "application/pdf", Data: []byte("%PDF")},
+			want: []ContentPart{{Type: "file", File: &File{Filename: "file.pdf", FileData: "data:application/pdf;base64,JVBERg=="}}},
+		},
+		{
+			name: "audio",
+			part: llm.AudioContent{Format: "wav", Data: []byte("RIFF")},
+			want: []ContentPart{{Type: "input_audio", InputAudio: &InputAudio{Data: "UklGRg==", Format: "wav"}}},
+		},
+		{
+			name: "file data",
+			part: llm.FileContent{Filename: "report.pdf", MIMEType: "application/pdf", Data: []byte("%PDF")},
+			want: []ContentPart{{Type: "file", File: &File{Filename: "report.pdf", FileData: "data:application/pdf;base64,JVBERg=="}}},
+		},
+		{
+			name: "file data without filename",
+			part: llm.FileContent{MIMEType: "application/pdf", Data: []byte("%PDF")},
+			want: []ContentPart{{Type: "file", File: &File{Filename: "file.pdf", FileData: "data:application/pdf;base64,JVBERg=="}}},
+		},
+		{
+			name: "file id",
+			part: llm.FileContent{FileID: "file-123"},
+			want: []ContentPart{{Type: "file", File: &File{FileID: "file-123"}}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, audio, err := makeContentParts([]llm.ContentPart{tt.part})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if audio != nil {
+				t.Fatalf("audio = %+v, want nil", audio)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parts = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakeContentPartsAudioOutput(t *testing.T) {
+	parts, audio, err := makeContentParts([]llm.ContentPart{
+		llm.TextContent{Text: "hello"},
+		llm.AudioOutputContent{ID: "audio_1", Format: "wav", Data: []byte("RIFF"), Transcript: "hello"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := len(parts), 1; got != want {
+		t.Fatalf("len(parts) = %d, want %d", got, want)
+	}
+
+	if got, want := audio, (&Audio{ID: "audio_1"}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("audio = %+v, want %+v", got, want)
+	}
+}
+
+func TestMakeContentPartsUnsupported(t *testing.T) {
+	_, _, err := makeContentParts([]llm.ContentPart{llm.ToolCallResponse{ToolCallID: "call_1"}})
+	if !errors.Is(err, llm.ErrUnsupportedContentPart) {
+		t.Fatalf("err = %v, want %v", err, llm.ErrUnsupportedContentPart)
+	}
+}
+
+func TestMakeFilename(t *testing.T) {
+	for mimeType, want := range map[string]string{
+		"application/pdf":      "file.pdf",
+		"application/x-nope-1": "file",
+		"":                     "file",
+	} {
+		if got := makeFilename(mimeType); got != want {
+			t.Fatalf("makeFilename(%q) = %q, want %q", mimeType, got, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// ImageRequest is a request to generate, edit or create variations of images.
+type ImageRequest struct {
+	Model          string `json:"model,omitempty"`
+	Prompt         string `json:"prompt,omitempty"`
+	N              int    `json:"n,omitempty"`
+	Size           string `json:"size,omitempty"`
+	Quality        string `json:"quality,omitempty"`
+	Style          string `json:"style,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"`
+
+	// Image and Mask are only used for edits and variations.
+	Image []byte `json:"-"`
+	Mask  []byte `json:"-"`
+}
+
+// ImageData is a generated image, either as an URL or base64 encoded data.
+type ImageData struct {
+	URL           string `json:"url,omitempty"`
+	B64JSON       string `json:"b64_json,omitempty"`
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
+}
+
+// ImageResponse is a response to an image request.
+type ImageResponse struct {
+	Created int64       `json:"created"`
+	Data    []ImageData `json:"data"`
+}
+
+// CreateImage generates images from a prompt.
+func (c *Client) CreateImage(ctx context.Context, r *ImageRequest) (*ImageResponse, error) {
+	if r.Model == "" {
+		r.Model = c.ImageModel
+	}
+
+	var response ImageResponse
+
+	if err := c.doJSON(ctx, "/images/generations", r.Model, r, &response); err != nil {
+		return nil, err
+	}
+
+	return checkImageResponse(&response)
+}
+
+// CreateImageEdit edits an image as described by the prompt.
+func (c *Client) CreateImageEdit(ctx context.Context, r *ImageRequest) (*ImageResponse, error) {
+	fields := r.fields()
+	fields["prompt"] = r.Prompt
+	fields["quality"] = r.Quality
+
+	files := []formFile{imageFile("image", r.Image)}
+
+	if len(r.Mask) > 0 {
+		files = append(files, imageFile("mask", r.Mask))
+	}
+
+	return c.doImageForm(ctx, "/images/edits", fields, files...)
+}
+
+// CreateImageVariation creates variations of an image.
+func (c *Client) CreateImageVariation(ctx context.Context, r *ImageRequest) (*ImageResponse, error) {
+	return c.doImageForm(ctx, "/images/variations", r.fields(), imageFile("image", r.Image))
+}
+
+func (c *Client) doImageForm(ctx context.Context, suffix string, fields map[string]string, files ...formFile) (*ImageResponse, error) {
+	if fields["model"] == "" {
+		fields["model"] = c.ImageModel
+	}
+
+	req, err := c.multipartRequest(ctx, suffix, fields["model"], fields, files...)
+	if err != nil {
+		return nil, err
+	}
+
+	var response ImageResponse
+
+	if err := c.do(req, &response); err != nil {
+		return nil, err
+	}
+
+	return checkImageResponse(&response)
+}
+
+// fields returns the form fields shared by edits and variations.
+func (r *ImageRequest) fields() map[string]string {
+	fields := map[string]string{
+		"model":           r.Model,
+		"size":            r.Size,
+		"response_format": r.ResponseFormat,
+	}
+
+	if r.N > 0 {
+		fields["n"] = strconv.Itoa(r.N)
+	}
+
+	return fields
+}
+
+func imageFile(field string, data []byte) formFile {
+	contentType := http.DetectContentType(data)
+
+	return formFile{
+		field:       field,
+		filename:    field + imageExtension(contentType),
+		contentType: contentType,
+		data:        data,
+	}
+}
+
+func imageExtension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ".png"
+	}
+}
+
+func checkImageResponse(response *ImageResponse) (*ImageResponse, error) {
+	if len(response.Data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return response, nil
+}
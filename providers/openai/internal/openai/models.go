@@ -0,0 +1,39 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Model is a model offered by the API.
+type Model struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+// ModelsList is a list of models.
+type ModelsList struct {
+	Object string  `json:"object"`
+	Data   []Model `json:"data"`
+}
+
+// ListModels lists the currently available models.
+func (c *Client) ListModels(ctx context.Context) (*ModelsList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.buildURL("/models", c.Model), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var response ModelsList
+
+	if err := c.do(req, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,48 @@
+package openai
+
+import "context"
+
+const defaultModerationModel = "omni-moderation-latest"
+
+// ModerationRequest is a request to classify if inputs are potentially harmful.
+type ModerationRequest struct {
+	Model string   `json:"model,omitempty"`
+	Input []string `json:"input"`
+}
+
+// ModerationResult is the moderation result for a single input.
+type ModerationResult struct {
+	Flagged        bool               `json:"flagged"`
+	Categories     map[string]bool    `json:"categories"`
+	CategoryScores map[string]float64 `json:"category_scores"`
+}
+
+// ModerationResponse is a response to a moderation request.
+type ModerationResponse struct {
+	ID      string             `json:"id"`
+	Model   string             `json:"model"`
+	Results []ModerationResult `json:"results"`
+}
+
+// CreateModeration classifies if the inputs are potentially harmful.
+func (c *Client) CreateModeration(ctx context.Context, r *ModerationRequest) (*ModerationResponse, error) {
+	if r.Model == "" {
+		r.Model = c.ModerationModel
+	}
+
+	if r.Model == "" {
+		r.Model = defaultModerationModel
+	}
+
+	var response ModerationResponse
+
+	if err := c.doJSON(ctx, "/moderations", r.Model, r, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Results) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &response, nil
+}
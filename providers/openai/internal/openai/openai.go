@@ -1,9 +1,14 @@
 package openai
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"strings"
 )
 
@@ -31,6 +36,13 @@ type Client struct {
 
 	EmbeddingModel string
 
+	ModerationModel string
+
+	ImageModel string
+
+	TranscriptionModel string
+	SpeechModel        string
+
 	// required when APIType is APITypeAzure or APITypeAzureAD
 	apiVersion string
 
@@ -76,6 +88,42 @@ func New(token string, model string, baseURL string, organization string,
 	return c, nil
 }
 
+// WithModerationModel sets the model used for moderation requests.
+func WithModerationModel(model string) Option {
+	return func(c *Client) error {
+		c.ModerationModel = model
+
+		return nil
+	}
+}
+
+// WithImageModel sets the model used for image requests.
+func WithImageModel(model string) Option {
+	return func(c *Client) error {
+		c.ImageModel = model
+
+		return nil
+	}
+}
+
+// WithTranscriptionModel sets the model used for transcription requests.
+func WithTranscriptionModel(model string) Option {
+	return func(c *Client) error {
+		c.TranscriptionModel = model
+
+		return nil
+	}
+}
+
+// WithSpeechModel sets the model used for speech requests.
+func WithSpeechModel(model string) Option {
+	return func(c *Client) error {
+		c.SpeechModel = model
+
+		return nil
+	}
+}
+
 // Completion is a completion.
 type Completion struct {
 	Text string `json:"text"`
@@ -170,6 +218,146 @@ func (c *Client) setHeaders(req *http.Request) {
 	}
 }
 
+// doJSON sends the payload as JSON to the given suffix and decodes the JSON response.
+func (c *Client) doJSON(ctx context.Context, suffix, model string, payload, response any) error {
+	req, err := c.jsonRequest(ctx, suffix, model, payload)
+	if err != nil {
+		return err
+	}
+
+	return c.do(req, response)
+}
+
+// jsonRequest creates a request with the payload as JSON.
+func (c *Client) jsonRequest(ctx context.Context, suffix, model string, payload any) (*http.Request, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.buildURL(suffix, model), bytes.NewReader(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	return req, nil
+}
+
+// formFile is a file sent in a multipart form.
+type formFile struct {
+	field       string
+	filename    string
+	contentType string
+	data        []byte
+}
+
+// multipartRequest creates a request with the fields and files as a multipart form.
+// Fields with empty values are not included.
+func (c *Client) multipartRequest(ctx context.Context, suffix, model string, fields map[string]string, files ...formFile) (*http.Request, error) {
+	var body bytes.Buffer
+
+	w := multipart.NewWriter(&body)
+
+	for _, f := range files {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, f.field, f.filename))
+		h.Set("Content-Type", f.contentType)
+
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return nil, fmt.Errorf("create form file: %w", err)
+		}
+
+		if _, err := part.Write(f.data); err != nil {
+			return nil, fmt.Errorf("write form file: %w", err)
+		}
+	}
+
+	for name, value := range fields {
+		if value == "" {
+			continue
+		}
+
+		if err := w.WriteField(name, value); err != nil {
+			return nil, fmt.Errorf("write form field: %w", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("close form: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.buildURL(suffix, model), &body)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return req, nil
+}
+
+// do sends the request and decodes the JSON response, if any.
+func (c *Client) do(req *http.Request, response any) error {
+	r, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer r.Body.Close()
+
+	if err := checkResponse(r); err != nil {
+		return err
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(response); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// doStream sends the request and returns the body of the response,
+// which must be closed by the caller.
+func (c *Client) doStream(req *http.Request) (io.ReadCloser, error) {
+	r, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+
+	if err := checkResponse(r); err != nil {
+		r.Body.Close()
+
+		return nil, err
+	}
+
+	return r.Body, nil
+}
+
+// checkResponse returns an error if the response has an unexpected status code.
+func checkResponse(r *http.Response) error {
+	if r.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	msg := fmt.Sprintf("API returned unexpected status code: %d", r.StatusCode)
+
+	var errResp errorMessage
+
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&errResp); err != nil {
+		return fmt.Errorf("%s", msg)
+	}
+
+	return fmt.Errorf("%s: %s", msg, errResp.Error.Message)
+}
+
 func (c *Client) buildURL(suffix string, model string) string {
 	if IsAzure(c.apiType) {
 		return c.buildAzureURL(suffix, model)
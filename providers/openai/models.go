@@ -0,0 +1,64 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/peterhellberg/llm"
+)
+
+var _ llm.ModelLister = (*Provider)(nil)
+
+// ListModels lists the models available through the OpenAI API.
+//
+// The API only reports the ID and owner of each model, so the type and
+// modalities are derived from well known model ID prefixes.
+func (o *Provider) ListModels(ctx context.Context) ([]llm.ModelInfo, error) {
+	list, err := o.client.ListModels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list openai models: %w", err)
+	}
+
+	models := make([]llm.ModelInfo, 0, len(list.Data))
+
+	for _, m := range list.Data {
+		typ, modalities := modelTypeAndModalities(m.ID)
+
+		models = append(models, llm.ModelInfo{
+			ID:         m.ID,
+			Type:       typ,
+			Modalities: modalities,
+			Metadata: map[string]any{
+				"created":  m.Created,
+				"owned_by": m.OwnedBy,
+			},
+		})
+	}
+
+	return models, nil
+}
+
+func modelTypeAndModalities(id string) (llm.ModelType, []string) {
+	switch {
+	case strings.Contains(id, "embedding"):
+		return llm.ModelTypeEmbedding, []string{"text"}
+	case strings.Contains(id, "moderation"):
+		return llm.ModelTypeModeration, []string{"text", "image"}
+	case strings.HasPrefix(id, "dall-e"), strings.HasPrefix(id, "gpt-image"):
+		return llm.ModelTypeImage, []string{"text", "image"}
+	case strings.HasPrefix(id, "whisper"), strings.HasPrefix(id, "tts"),
+		strings.Contains(id, "transcribe"), strings.Contains(id, "-tts"):
+		return llm.ModelTypeAudio, []string{"audio"}
+	case strings.Contains(id, "audio"), strings.Contains(id, "realtime"):
+		return llm.ModelTypeChat, []string{"text", "audio"}
+	case strings.HasPrefix(id, "gpt-4o"), strings.HasPrefix(id, "gpt-4.1"),
+		strings.HasPrefix(id, "gpt-4-turbo"), strings.HasPrefix(id, "gpt-5"),
+		strings.HasPrefix(id, "o1"), strings.HasPrefix(id, "o3"), strings.HasPrefix(id, "o4"):
+		return llm.ModelTypeChat, []string{"text", "image"}
+	case strings.HasPrefix(id, "gpt-"), strings.HasPrefix(id, "chatgpt"):
+		return llm.ModelTypeChat, []string{"text"}
+	default:
+		return llm.ModelTypeUnknown, nil
+	}
+}
@@ -0,0 +1,71 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+)
+
+func TestProviderListModels(t *testing.T) {
+	var method, path string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+
+		w.Write([]byte(`{"object":"list","data":[
+			{"id":"gpt-4o","object":"model","created":1715367049,"owned_by":"system"},
+			{"id":"text-embedding-3-small","object":"model","created":1705948997,"owned_by":"system"},
+			{"id":"whisper-1","object":"model","created":1677532384,"owned_by":"openai-internal"},
+			{"id":"my-fine-tune","object":"model","created":1700000000,"owned_by":"user-abc"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	p, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	models, err := p.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := method+" "+path, "GET /models"; got != want {
+		t.Fatalf("request = %q, want %q", got, want)
+	}
+
+	want := []llm.ModelInfo{
+		{
+			ID:         "gpt-4o",
+			Type:       llm.ModelTypeChat,
+			Modalities: []string{"text", "image"},
+			Metadata:   map[string]any{"created": int64(1715367049), "owned_by": "system"},
+		},
+		{
+			ID:         "text-embedding-3-small",
+			Type:       llm.ModelTypeEmbedding,
+			Modalities: []string{"text"},
+			Metadata:   map[string]any{"created": int64(1705948997), "owned_by": "system"},
+		},
+		{
+			ID:         "whisper-1",
+			Type:       llm.ModelTypeAudio,
+			Modalities: []string{"audio"},
+			Metadata:   map[string]any{"created": int64(1677532384), "owned_by": "openai-internal"},
+		},
+		{
+			ID:       "my-fine-tune",
+			Type:     llm.ModelTypeUnknown,
+			Metadata: map[string]any{"created": int64(1700000000), "owned_by": "user-abc"},
+		},
+	}
+
+	if !reflect.DeepEqual(models, want) {
+		t.Fatalf("models = %+v, want %+v", models, want)
+	}
+}
@@ -0,0 +1,72 @@
+package openai
+
+import (
+	"fmt"
+
+	"github.com/peterhellberg/llm"
+)
+
+func init() {
+	llm.RegisterProvider("openai", func(dsn *llm.DSN, env llm.Env) (llm.Provider, error) {
+		if dsn.Model == "" {
+			return open(dsn, env)
+		}
+
+		return open(dsn, env, WithModel(dsn.Model))
+	})
+
+	llm.RegisterEmbedder("openai", func(dsn *llm.DSN, env llm.Env) (llm.EmbedderClient, error) {
+		if dsn.Model == "" {
+			return open(dsn, env)
+		}
+
+		return open(dsn, env, WithEmbeddingModel(dsn.Model))
+	})
+}
+
+// open creates a Provider for a DSN such as "openai:gpt-4o?base_url=http://localhost:8080/v1".
+// The token and other settings not part of the DSN are read from env, using the same
+// environment variables as New. The DSN can not have a host, use the base_url param instead.
+// Other supported params are organization, api_type, api_version, embedding_model and
+// response_format=json.
+func open(dsn *llm.DSN, env llm.Env, opts ...Option) (*Provider, error) {
+	if dsn.Host != "" {
+		return nil, fmt.Errorf("%w: host %q is not supported, use the base_url param", llm.ErrInvalidDSN, dsn.Host)
+	}
+
+	params := dsn.Env()
+
+	if baseURL := params.Get("base_url"); baseURL != "" {
+		opts = append(opts, WithBaseURL(baseURL))
+	}
+
+	if organization := params.Get("organization"); organization != "" {
+		opts = append(opts, WithOrganization(organization))
+	}
+
+	if apiType := params.Get("api_type"); apiType != "" {
+		opts = append(opts, WithAPIType(APIType(apiType)))
+	}
+
+	if apiVersion := params.Get("api_version"); apiVersion != "" {
+		opts = append(opts, WithAPIVersion(apiVersion))
+	}
+
+	if embeddingModel := params.Get("embedding_model"); embeddingModel != "" {
+		opts = append(opts, WithEmbeddingModel(embeddingModel))
+	}
+
+	if params.Get("response_format") == "json" {
+		opts = append(opts, WithResponseFormat(ResponseFormatJSON))
+	}
+
+	opt, c, err := newClient(env.Get, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		client: c,
+		hooks:  opt.hooks,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+)
+
+func TestOpen(t *testing.T) {
+	var (
+		auth string
+		req  map[string]any
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hello"}}]}`))
+	}))
+	defer srv.Close()
+
+	env := llm.NewEnv(func(key string) string {
+		return map[string]string{
+			"OPENAI_API_KEY": "token",
+			"SECRET":         "secret",
+		}[key]
+	})
+
+	p, err := llm.Open("openai:gpt-4o?api_key_env=SECRET&base_url="+srv.URL, llm.WithOpenEnv(env))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := llm.Call(context.Background(), p, "hi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "hello"; got != want {
+		t.Fatalf("got = %q, want %q", got, want)
+	}
+
+	if got, want := req["model"], "gpt-4o"; got != want {
+		t.Fatalf(`req["model"] = %v, want %v`, got, want)
+	}
+
+	if got, want := auth, "Bearer token"; got != want {
+		t.Fatalf("auth = %q, want %q", got, want)
+	}
+
+	if _, err := llm.Open("openai://example.com/gpt-4o", llm.WithOpenEnv(env)); !errors.Is(err, llm.ErrInvalidDSN) {
+		t.Fatalf("err = %v, want %v", err, llm.ErrInvalidDSN)
+	}
+}
@@ -2,6 +2,7 @@ package openai
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"os"
@@ -13,6 +14,7 @@ import (
 var (
 	_ llm.Provider       = (*Provider)(nil)
 	_ llm.EmbedderClient = (*Provider)(nil)
+	_ llm.Moderator      = (*Provider)(nil)
 )
 
 // Provider is an llm.Provider implementation for OpenAI.
@@ -54,6 +56,7 @@ func (o *Provider) GenerateContent(ctx context.Context, messages []llm.Message,
 
 	for _, mc := range messages {
 		msg := &openai.ChatMessage{
+			Name:         mc.Name,
 			MultiContent: mc.Parts,
 		}
 
@@ -108,7 +111,17 @@ func (o *Provider) GenerateContent(ctx context.Context, messages []llm.Message,
 		ToolChoice: opts.ToolChoice,
 		Seed:       opts.Seed,
 		Metadata:   opts.Metadata,
+
+		Modalities: opts.Modalities,
 	}
+
+	if opts.Audio != nil {
+		req.Audio = &openai.AudioOutput{
+			Voice:  opts.Audio.Voice,
+			Format: opts.Audio.Format,
+		}
+	}
+
 	if opts.JSONMode {
 		req.ResponseFormat = ResponseFormatJSON
 	}
@@ -162,6 +175,19 @@ func (o *Provider) GenerateContent(ctx context.Context, messages []llm.Message,
 			})
 		}
 
+		if audio := c.Message.Audio; audio != nil {
+			choice, err := makeAudioOutput(audio, opts.Audio)
+			if err != nil {
+				return nil, err
+			}
+
+			choices[i].Audio = choice
+
+			if choices[i].Content == "" {
+				choices[i].Content = audio.Transcript
+			}
+		}
+
 		// populate legacy single-function call field for backwards compatibility
 		if len(choices[i].ToolCalls) > 0 {
 			choices[i].FuncCall = choices[i].ToolCalls[0].FunctionCall
@@ -197,6 +223,32 @@ func (o *Provider) CreateEmbedding(ctx context.Context, inputTexts []string) ([]
 	return embeddings, nil
 }
 
+// Moderate classifies if the inputs are potentially harmful.
+func (o *Provider) Moderate(ctx context.Context, inputs []string) ([]llm.ModerationResult, error) {
+	resp, err := o.client.CreateModeration(ctx, &openai.ModerationRequest{
+		Input: inputs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create openai moderation: %w", err)
+	}
+
+	if len(inputs) != len(resp.Results) {
+		return nil, ErrUnexpectedResponseLength
+	}
+
+	results := make([]llm.ModerationResult, len(resp.Results))
+
+	for i, r := range resp.Results {
+		results[i] = llm.ModerationResult{
+			Flagged:        r.Flagged,
+			Categories:     r.Categories,
+			CategoryScores: r.CategoryScores,
+		}
+	}
+
+	return results, nil
+}
+
 // ExtractToolParts extracts the tool parts from a message.
 func ExtractToolParts(msg *openai.ChatMessage) ([]llm.ContentPart, []llm.ToolCall) {
 	var (
@@ -212,6 +264,12 @@ func ExtractToolParts(msg *openai.ChatMessage) ([]llm.ContentPart, []llm.ToolCal
 			content = append(content, p)
 		case llm.BinaryContent:
 			content = append(content, p)
+		case llm.AudioContent:
+			content = append(content, p)
+		case llm.FileContent:
+			content = append(content, p)
+		case llm.AudioOutputContent:
+			content = append(content, p)
 		case llm.ToolCall:
 			toolCalls = append(toolCalls, p)
 		}
@@ -220,6 +278,27 @@ func ExtractToolParts(msg *openai.ChatMessage) ([]llm.ContentPart, []llm.ToolCal
 	return content, toolCalls
 }
 
+// makeAudioOutput converts the audio generated by the model into an llm.AudioOutputContent.
+func makeAudioOutput(audio *openai.Audio, opts *llm.AudioOutputOptions) (*llm.AudioOutputContent, error) {
+	data, err := base64.StdEncoding.DecodeString(audio.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode openai audio: %w", err)
+	}
+
+	output := &llm.AudioOutputContent{
+		ID:         audio.ID,
+		Data:       data,
+		Transcript: audio.Transcript,
+		ExpiresAt:  audio.ExpiresAt,
+	}
+
+	if opts != nil {
+		output.Format = opts.Format
+	}
+
+	return output, nil
+}
+
 // toolFromTool converts an llm.Tool to a Tool.
 func toolFromTool(t llm.Tool) (openai.Tool, error) {
 	tool := openai.Tool{
@@ -305,6 +384,10 @@ func newClient(getenv llm.Getenv, opts ...Option) (*options, *openai.Client, err
 		options.httpClient,
 		options.embeddingModel,
 		options.responseFormat,
+		openai.WithModerationModel(options.moderationModel),
+		openai.WithImageModel(options.imageModel),
+		openai.WithTranscriptionModel(options.transcriptionModel),
+		openai.WithSpeechModel(options.speechModel),
 	)
 
 	return options, cli, err
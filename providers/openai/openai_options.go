@@ -37,6 +37,12 @@ type options struct {
 	apiVersion     string
 	embeddingModel string
 
+	moderationModel string
+	imageModel      string
+
+	transcriptionModel string
+	speechModel        string
+
 	hooks llm.ProviderHooks
 }
 
@@ -79,6 +85,38 @@ func WithEmbeddingModel(embeddingModel string) Option {
 	}
 }
 
+// WithModerationModel passes the OpenAI moderation model to the client.
+// If not set, the default value is omni-moderation-latest.
+func WithModerationModel(moderationModel string) Option {
+	return func(opts *options) {
+		opts.moderationModel = moderationModel
+	}
+}
+
+// WithImageModel passes the OpenAI image model to the client, e.g. "dall-e-3" or "gpt-image-1".
+// If not set, the model is chosen by the API unless set using llm.WithImageModel.
+func WithImageModel(imageModel string) Option {
+	return func(opts *options) {
+		opts.imageModel = imageModel
+	}
+}
+
+// WithTranscriptionModel passes the OpenAI transcription model to the client.
+// If not set, the default value is whisper-1.
+func WithTranscriptionModel(transcriptionModel string) Option {
+	return func(opts *options) {
+		opts.transcriptionModel = transcriptionModel
+	}
+}
+
+// WithSpeechModel passes the OpenAI text-to-speech model to the client.
+// If not set, the default value is gpt-4o-mini-tts.
+func WithSpeechModel(speechModel string) Option {
+	return func(opts *options) {
+		opts.speechModel = speechModel
+	}
+}
+
 // WithBaseURL passes the OpenAI base url to the client. If not set, the base url
 // is read from the OPENAI_BASE_URL environment variable. If still not set in ENV
 // VAR OPENAI_BASE_URL, then the default value is https://api.openai.com/v1 is used.
@@ -0,0 +1,186 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+)
+
+func TestProviderModerate(t *testing.T) {
+	var (
+		path string
+		req  map[string]any
+		resp = `{"id":"modr-1","model":"omni-moderation-latest","results":[
+			{"flagged":false,"categories":{"violence":false},"category_scores":{"violence":0.01}},
+			{"flagged":true,"categories":{"violence":true},"category_scores":{"violence":0.98}}
+		]}`
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		w.Write([]byte(resp))
+	}))
+	defer srv.Close()
+
+	p, err := New(WithToken("test"), WithBaseURL(srv.URL), WithModerationModel("text-moderation-latest"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results, err := p.Moderate(context.Background(), []string{"hello", "hit them"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := path, "/moderations"; got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+
+	if got, want := req["model"], "text-moderation-latest"; got != want {
+		t.Fatalf(`req["model"] = %v, want %v`, got, want)
+	}
+
+	if got, want := req["input"], []any{"hello", "hit them"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf(`req["input"] = %v, want %v`, got, want)
+	}
+
+	if got, want := len(results), 2; got != want {
+		t.Fatalf("len(results) = %d, want %d", got, want)
+	}
+
+	if results[0].Flagged || !results[1].Flagged {
+		t.Fatalf("flagged = %v, %v, want false, true", results[0].Flagged, results[1].Flagged)
+	}
+
+	if got, want := results[1].Categories["violence"], true; got != want {
+		t.Fatalf(`results[1].Categories["violence"] = %v, want %v`, got, want)
+	}
+
+	if got, want := results[1].CategoryScores["violence"], 0.98; got != want {
+		t.Fatalf(`results[1].CategoryScores["violence"] = %v, want %v`, got, want)
+	}
+
+	if _, err := p.Moderate(context.Background(), []string{"hello"}); !errors.Is(err, ErrUnexpectedResponseLength) {
+		t.Fatalf("err = %v, want %v", err, ErrUnexpectedResponseLength)
+	}
+}
+
+func TestProviderGenerateContentAudioOutput(t *testing.T) {
+	var reqs []map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		reqs = append(reqs, req)
+
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","audio":` +
+			`{"id":"audio_1","data":"UklGRg==","expires_at":1700000000,"transcript":"hello"}}}]}`))
+	}))
+	defer srv.Close()
+
+	p, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	messages := []llm.Message{llm.TextParts(llm.ChatMessageTypeHuman, "say hello")}
+
+	resp, err := p.GenerateContent(context.Background(), messages, llm.WithAudioOutput("alloy", "wav"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := &llm.AudioOutputContent{
+		ID:         "audio_1",
+		Format:     "wav",
+		Data:       []byte("RIFF"),
+		Transcript: "hello",
+		ExpiresAt:  1700000000,
+	}
+
+	choice := resp.Choices[0]
+
+	if !reflect.DeepEqual(choice.Audio, want) {
+		t.Fatalf("choice.Audio = %+v, want %+v", choice.Audio, want)
+	}
+
+	if got, want := choice.Content, "hello"; got != want {
+		t.Fatalf("choice.Content = %q, want %q", got, want)
+	}
+
+	if got, want := reqs[0]["audio"], map[string]any{"voice": "alloy", "format": "wav"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf(`reqs[0]["audio"] = %v, want %v`, got, want)
+	}
+
+	if got, want := reqs[0]["modalities"], []any{"text", "audio"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf(`reqs[0]["modalities"] = %v, want %v`, got, want)
+	}
+
+	messages = append(messages,
+		llm.Message{Role: llm.ChatMessageTypeAI, Parts: []llm.ContentPart{*choice.Audio}},
+		llm.TextParts(llm.ChatMessageTypeHuman, "again"),
+	)
+
+	if _, err := p.GenerateContent(context.Background(), messages, llm.WithAudioOutput("alloy", "wav")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg := reqs[1]["messages"].([]any)[1].(map[string]any)
+
+	if got, want := msg["audio"], map[string]any{"id": "audio_1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf(`msg["audio"] = %v, want %v`, got, want)
+	}
+}
+
+func TestProviderGenerateContentMessageName(t *testing.T) {
+	var req struct {
+		Messages []map[string]any `json:"messages"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	defer srv.Close()
+
+	p, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	messages := []llm.Message{
+		llm.ChatMessageToMessage(llm.GenericChatMessage{Role: "critic", Name: "bob", Content: "too long"}),
+		llm.TextParts(llm.ChatMessageTypeHuman, "shorten it"),
+	}
+
+	if _, err := p.GenerateContent(context.Background(), messages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []map[string]any{
+		{"role": "user", "name": "bob", "content": "too long"},
+		{"role": "user", "content": "shorten it"},
+	}
+
+	if !reflect.DeepEqual(req.Messages, want) {
+		t.Fatalf("messages = %v, want %v", req.Messages, want)
+	}
+}
@@ -0,0 +1,202 @@
+package llm
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ProviderFactory creates a Provider from a DSN, using env for secrets.
+type ProviderFactory func(dsn *DSN, env Env) (Provider, error)
+
+// EmbedderFactory creates an EmbedderClient from a DSN, using env for secrets.
+type EmbedderFactory func(dsn *DSN, env Env) (EmbedderClient, error)
+
+var registry = struct {
+	sync.RWMutex
+
+	providers map[string]ProviderFactory
+	embedders map[string]EmbedderFactory
+}{
+	providers: map[string]ProviderFactory{},
+	embedders: map[string]EmbedderFactory{},
+}
+
+// RegisterProvider makes a provider available by the scheme used in Open.
+// It is typically called from the init function of the provider package.
+// RegisterProvider panics if the factory is nil or the scheme is already registered.
+func RegisterProvider(scheme string, factory ProviderFactory) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if factory == nil {
+		panic("llm: RegisterProvider factory is nil")
+	}
+
+	if _, dup := registry.providers[scheme]; dup {
+		panic("llm: RegisterProvider called twice for scheme " + scheme)
+	}
+
+	registry.providers[scheme] = factory
+}
+
+// RegisterEmbedder makes an embedder available by the scheme used in OpenEmbedder.
+// RegisterEmbedder panics if the factory is nil or the scheme is already registered.
+func RegisterEmbedder(scheme string, factory EmbedderFactory) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if factory == nil {
+		panic("llm: RegisterEmbedder factory is nil")
+	}
+
+	if _, dup := registry.embedders[scheme]; dup {
+		panic("llm: RegisterEmbedder called twice for scheme " + scheme)
+	}
+
+	registry.embedders[scheme] = factory
+}
+
+// Providers returns the sorted schemes of the registered providers.
+func Providers() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	schemes := make([]string, 0, len(registry.providers))
+
+	for scheme := range registry.providers {
+		schemes = append(schemes, scheme)
+	}
+
+	slices.Sort(schemes)
+
+	return schemes
+}
+
+// OpenOption is a function that configures Open and OpenEmbedder.
+type OpenOption func(*openOptions)
+
+type openOptions struct {
+	env          Env
+	embedderOpts []EmbedderOption
+}
+
+// WithOpenEnv sets the Env used by the factory to look up secrets, such as API keys.
+// Defaults to an Env backed by os.Getenv.
+func WithOpenEnv(env Env) OpenOption {
+	return func(o *openOptions) {
+		o.env = env
+	}
+}
+
+// WithEmbedderOptions sets the options passed to NewEmbedder by OpenEmbedder.
+func WithEmbedderOptions(opts ...EmbedderOption) OpenOption {
+	return func(o *openOptions) {
+		o.embedderOpts = opts
+	}
+}
+
+// Open creates a Provider from a DSN using the factory registered for its scheme,
+// e.g. "ollama://localhost:11434/llama3?keep_alive=5m" or "openai:gpt-4o".
+// The package of the provider must be imported for the scheme to be registered.
+func Open(dsn string, options ...OpenOption) (Provider, error) {
+	d, opts, err := parseOpen(dsn, options)
+	if err != nil {
+		return nil, err
+	}
+
+	registry.RLock()
+	factory, ok := registry.providers[d.Scheme]
+	registry.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScheme, d.Scheme)
+	}
+
+	return factory(d, opts.env)
+}
+
+// OpenEmbedder creates an Embedder from a DSN using the factory registered for its
+// scheme, where the model of the DSN is the embedding model, e.g. "openai:text-embedding-3-small".
+func OpenEmbedder(dsn string, options ...OpenOption) (Embedder, error) {
+	d, opts, err := parseOpen(dsn, options)
+	if err != nil {
+		return nil, err
+	}
+
+	registry.RLock()
+	factory, ok := registry.embedders[d.Scheme]
+	registry.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScheme, d.Scheme)
+	}
+
+	client, err := factory(d, opts.env)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEmbedder(client, opts.embedderOpts...)
+}
+
+func parseOpen(dsn string, options []OpenOption) (*DSN, openOptions, error) {
+	opts := openOptions{
+		env: NewEnv(os.Getenv),
+	}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	d, err := ParseDSN(dsn)
+
+	return d, opts, err
+}
+
+// DSN is a parsed data source name used to open a provider, either with a
+// host as in "scheme://host:port/model?key=value" or without one as in
+// "scheme:model?key=value".
+type DSN struct {
+	// Scheme is the scheme used to find the registered factory, e.g. "ollama".
+	Scheme string
+	// Host is the host and optional port, if any.
+	Host string
+	// Model is the name of the model, if any.
+	Model string
+	// Params are the query parameters.
+	Params url.Values
+}
+
+// ParseDSN parses the data source name.
+func ParseDSN(dsn string) (*DSN, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDSN, err)
+	}
+
+	if u.Scheme == "" {
+		return nil, fmt.Errorf("%w: missing scheme in %q", ErrInvalidDSN, dsn)
+	}
+
+	model := u.Opaque
+
+	if model == "" {
+		model = strings.TrimPrefix(u.Path, "/")
+	}
+
+	return &DSN{
+		Scheme: u.Scheme,
+		Host:   u.Host,
+		Model:  model,
+		Params: u.Query(),
+	}, nil
+}
+
+// Env returns the params of the DSN as an Env, for typed access with fallbacks.
+func (d *DSN) Env() Env {
+	return NewEnv(d.Params.Get)
+}
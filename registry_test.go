@@ -0,0 +1,98 @@
+package llm_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/peterhellberg/llm"
+	"github.com/peterhellberg/llm/mock"
+)
+
+func TestParseDSN(t *testing.T) {
+	for _, tt := range []struct {
+		dsn  string
+		want llm.DSN
+	}{
+		{
+			dsn: "ollama://localhost:11434/llama3?keep_alive=5m",
+			want: llm.DSN{
+				Scheme: "ollama",
+				Host:   "localhost:11434",
+				Model:  "llama3",
+				Params: map[string][]string{"keep_alive": {"5m"}},
+			},
+		},
+		{
+			dsn: "openai:gpt-4o?base_url=http://localhost:8080/v1",
+			want: llm.DSN{
+				Scheme: "openai",
+				Model:  "gpt-4o",
+				Params: map[string][]string{"base_url": {"http://localhost:8080/v1"}},
+			},
+		},
+		{
+			dsn: "ollama:llama3:8b",
+			want: llm.DSN{
+				Scheme: "ollama",
+				Model:  "llama3:8b",
+				Params: map[string][]string{},
+			},
+		},
+	} {
+		got, err := llm.ParseDSN(tt.dsn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(*got, tt.want) {
+			t.Fatalf("ParseDSN(%q) = %#v, want %#v", tt.dsn, *got, tt.want)
+		}
+	}
+
+	if _, err := llm.ParseDSN("llama3"); !errors.Is(err, llm.ErrInvalidDSN) {
+		t.Fatalf("err = %v, want %v", err, llm.ErrInvalidDSN)
+	}
+}
+
+// registerTestProvider registers the "test" scheme once, since the registry
+// is global and RegisterProvider panics if called twice, e.g. with -count=2.
+var registerTestProvider = sync.OnceFunc(func() {
+	llm.RegisterProvider("test", func(dsn *llm.DSN, env llm.Env) (llm.Provider, error) {
+		return mock.Provider{
+			GenerateContentFunc: func(context.Context, []llm.Message, ...llm.ContentOption) (*llm.ContentResponse, error) {
+				return &llm.ContentResponse{Choices: []*llm.ContentChoice{
+					{Content: dsn.Model + " " + env.Get(dsn.Params.Get("key_env"))},
+				}}, nil
+			},
+		}, nil
+	})
+})
+
+func TestOpen(t *testing.T) {
+	registerTestProvider()
+
+	env := llm.NewEnv(func(key string) string {
+		return map[string]string{"TEST_KEY": "secret"}[key]
+	})
+
+	p, err := llm.Open("test:model?key_env=TEST_KEY", llm.WithOpenEnv(env))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := llm.Call(context.Background(), p, "hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "model secret"; got != want {
+		t.Fatalf("got = %q, want %q", got, want)
+	}
+
+	if _, err := llm.Open("unknown:model"); !errors.Is(err, llm.ErrUnknownScheme) {
+		t.Fatalf("err = %v, want %v", err, llm.ErrUnknownScheme)
+	}
+}
@@ -0,0 +1,146 @@
+package llm
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Transcriber is the interface for transcribing speech into text.
+type Transcriber interface {
+	// Transcribe reads all audio from r and transcribes it.
+	Transcribe(ctx context.Context, r io.Reader, options ...TranscriptionOption) (*Transcription, error)
+}
+
+// SpeechSynthesizer is the interface for synthesizing speech from text.
+type SpeechSynthesizer interface {
+	// SynthesizeSpeech returns a stream of audio with the text spoken.
+	// The returned io.ReadCloser must be closed by the caller.
+	SynthesizeSpeech(ctx context.Context, text string, options ...SpeechOption) (io.ReadCloser, error)
+}
+
+// Transcription is the text transcribed from audio.
+type Transcription struct {
+	// Text is the full transcribed text.
+	Text string
+	// Language is the detected or given language of the audio.
+	Language string
+	// Duration is the duration of the audio.
+	Duration time.Duration
+	// Segments are the timestamped segments of the text, if supported by the model.
+	Segments []TranscriptionSegment
+}
+
+// TranscriptionSegment is a segment of transcribed text with timestamps.
+type TranscriptionSegment struct {
+	// Start is the time in the audio where the segment starts.
+	Start time.Duration
+	// End is the time in the audio where the segment ends.
+	End time.Duration
+	// Text is the transcribed text of the segment.
+	Text string
+}
+
+// TranscriptionOption is a function that configures TranscriptionOptions.
+type TranscriptionOption func(*TranscriptionOptions)
+
+// TranscriptionOptions is a set of options for transcribing audio.
+type TranscriptionOptions struct {
+	// Model is the model to use.
+	Model string `json:"model"`
+	// Language is the language of the audio, as an ISO-639-1 code such as "en".
+	Language string `json:"language"`
+	// Prompt is text to guide the style of the transcription, or to continue a previous segment.
+	Prompt string `json:"prompt"`
+	// Temperature is the sampling temperature, between 0 and 1.
+	Temperature float64 `json:"temperature"`
+	// Filename is the name of the audio file, used by some providers to determine the audio format.
+	Filename string `json:"filename"`
+}
+
+// WithTranscriptionModel specifies which model to use for transcription.
+func WithTranscriptionModel(model string) TranscriptionOption {
+	return func(o *TranscriptionOptions) {
+		o.Model = model
+	}
+}
+
+// WithTranscriptionLanguage specifies the language of the audio, e.g. "en".
+func WithTranscriptionLanguage(language string) TranscriptionOption {
+	return func(o *TranscriptionOptions) {
+		o.Language = language
+	}
+}
+
+// WithTranscriptionPrompt specifies a prompt to guide the transcription.
+func WithTranscriptionPrompt(prompt string) TranscriptionOption {
+	return func(o *TranscriptionOptions) {
+		o.Prompt = prompt
+	}
+}
+
+// WithTranscriptionTemperature specifies the sampling temperature used for transcription.
+func WithTranscriptionTemperature(temperature float64) TranscriptionOption {
+	return func(o *TranscriptionOptions) {
+		o.Temperature = temperature
+	}
+}
+
+// WithAudioFilename specifies the filename of the audio, e.g. "recording.mp3".
+func WithAudioFilename(filename string) TranscriptionOption {
+	return func(o *TranscriptionOptions) {
+		o.Filename = filename
+	}
+}
+
+// SpeechOption is a function that configures SpeechOptions.
+type SpeechOption func(*SpeechOptions)
+
+// SpeechOptions is a set of options for synthesizing speech.
+type SpeechOptions struct {
+	// Model is the model to use.
+	Model string `json:"model"`
+	// Voice is the voice to use, e.g. "alloy".
+	Voice string `json:"voice"`
+	// Format is the audio format, e.g. "mp3", "wav" or "pcm".
+	Format string `json:"format"`
+	// Speed is the speed of the speech, where 1 is normal speed.
+	Speed float64 `json:"speed"`
+	// Instructions describe how the text should be spoken, e.g. the tone of voice.
+	Instructions string `json:"instructions"`
+}
+
+// WithSpeechModel specifies which model to use for speech synthesis.
+func WithSpeechModel(model string) SpeechOption {
+	return func(o *SpeechOptions) {
+		o.Model = model
+	}
+}
+
+// WithVoice specifies the voice to use for speech synthesis.
+func WithVoice(voice string) SpeechOption {
+	return func(o *SpeechOptions) {
+		o.Voice = voice
+	}
+}
+
+// WithSpeechFormat specifies the audio format of the synthesized speech.
+func WithSpeechFormat(format string) SpeechOption {
+	return func(o *SpeechOptions) {
+		o.Format = format
+	}
+}
+
+// WithSpeechSpeed specifies the speed of the synthesized speech.
+func WithSpeechSpeed(speed float64) SpeechOption {
+	return func(o *SpeechOptions) {
+		o.Speed = speed
+	}
+}
+
+// WithSpeechInstructions specifies how the text should be spoken.
+func WithSpeechInstructions(instructions string) SpeechOption {
+	return func(o *SpeechOptions) {
+		o.Instructions = instructions
+	}
+}
@@ -0,0 +1,247 @@
+// Package vision prepares images for vision requests by downsizing and
+// re-encoding them, and estimates how many tokens they will cost.
+package vision
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+
+	// Register the GIF decoder, JPEG and PNG are registered by the imports above.
+	_ "image/gif"
+
+	"github.com/peterhellberg/llm"
+)
+
+// Format is the format an image is encoded as.
+type Format string
+
+// Formats supported by Encode.
+const (
+	FormatJPEG Format = "jpeg"
+	FormatPNG  Format = "png"
+)
+
+// Detail levels used when estimating tokens and creating image URL content.
+const (
+	DetailLow  = "low"
+	DetailHigh = "high"
+	DetailAuto = "auto"
+)
+
+const (
+	defaultMaxDimension = 2048
+	defaultQuality      = 85
+)
+
+// ErrUnsupportedFormat is returned when encoding to an unsupported format.
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// Image is an encoded image, ready to be sent to a provider.
+type Image struct {
+	// Data is the encoded image.
+	Data []byte
+	// MIMEType is the MIME type of the encoded image, e.g. "image/jpeg".
+	MIMEType string
+	// Width of the image in pixels.
+	Width int
+	// Height of the image in pixels.
+	Height int
+}
+
+// BinaryContent returns the image as binary content.
+func (i Image) BinaryContent() llm.BinaryContent {
+	return llm.BinaryPart(i.MIMEType, i.Data)
+}
+
+// ImageURLContent returns the image as a data URL with the given detail.
+func (i Image) ImageURLContent(detail string) llm.ImageURLContent {
+	return llm.ImageURLWithDetailPart(i.BinaryContent().String(), detail)
+}
+
+// Tokens returns the estimated number of tokens for the image, see EstimateTokens.
+func (i Image) Tokens(detail string) int {
+	return EstimateTokens(i.Width, i.Height, detail)
+}
+
+// Option is a function that configures Prepare.
+type Option func(*options)
+
+type options struct {
+	maxDimension int
+	format       Format
+	quality      int
+}
+
+// WithMaxDimension sets the maximum width and height of the prepared image,
+// defaults to 2048. A value of zero or less keeps the original size.
+func WithMaxDimension(maxDimension int) Option {
+	return func(o *options) {
+		o.maxDimension = maxDimension
+	}
+}
+
+// WithFormat sets the format of the prepared image, defaults to FormatJPEG.
+func WithFormat(format Format) Option {
+	return func(o *options) {
+		o.format = format
+	}
+}
+
+// WithQuality sets the JPEG quality, between 1 and 100. Defaults to 85.
+func WithQuality(quality int) Option {
+	return func(o *options) {
+		o.quality = quality
+	}
+}
+
+// PrepareFile prepares the image in the file at path, see Prepare.
+func PrepareFile(path string, opts ...Option) (Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Image{}, err
+	}
+	defer f.Close()
+
+	return Prepare(f, opts...)
+}
+
+// Prepare decodes the image read from r, downsizes it so that it fits
+// within the max dimension and encodes it using the configured format.
+func Prepare(r io.Reader, opts ...Option) (Image, error) {
+	o := options{
+		maxDimension: defaultMaxDimension,
+		format:       FormatJPEG,
+		quality:      defaultQuality,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return Image{}, fmt.Errorf("decode image: %w", err)
+	}
+
+	img = Resize(img, o.maxDimension)
+
+	var buf bytes.Buffer
+
+	if err := Encode(&buf, img, o.format, o.quality); err != nil {
+		return Image{}, err
+	}
+
+	b := img.Bounds()
+
+	return Image{
+		Data:     buf.Bytes(),
+		MIMEType: "image/" + string(o.format),
+		Width:    b.Dx(),
+		Height:   b.Dy(),
+	}, nil
+}
+
+// Encode writes the image to w in the given format.
+// The quality is only used for JPEG images.
+func Encode(w io.Writer, img image.Image, format Format, quality int) error {
+	switch format {
+	case FormatJPEG:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	case FormatPNG:
+		return png.Encode(w, img)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// Resize downsizes the image, keeping its aspect ratio, so that neither the width
+// nor the height is larger than maxDimension. Each pixel in the resized image is
+// the average of the pixels it covers in the original image. Images that already
+// fit are returned as is.
+func Resize(img image.Image, maxDimension int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	if maxDimension <= 0 || (w <= maxDimension && h <= maxDimension) {
+		return img
+	}
+
+	nw, nh := fit(w, h, maxDimension)
+
+	dst := image.NewRGBA64(image.Rect(0, 0, nw, nh))
+
+	for y := range nh {
+		y0, y1 := b.Min.Y+y*h/nh, b.Min.Y+(y+1)*h/nh
+
+		for x := range nw {
+			x0, x1 := b.Min.X+x*w/nw, b.Min.X+(x+1)*w/nw
+
+			var r, g, bl, a, n uint64
+
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(bl / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
+
+// EstimateTokens estimates the number of tokens for an image of the given size,
+// using the tile based calculation from OpenAI. Low detail images cost a fixed
+// 85 tokens. Other images are scaled to fit within 2048x2048, then so that the
+// shortest side is at most 768 pixels, and cost 170 tokens per 512x512 tile
+// plus 85 tokens.
+func EstimateTokens(width, height int, detail string) int {
+	const (
+		baseTokens = 85
+		tileTokens = 170
+		tileSize   = 512
+	)
+
+	if detail == DetailLow || width <= 0 || height <= 0 {
+		return baseTokens
+	}
+
+	width, height = fit(width, height, 2048)
+
+	if shortest := min(width, height); shortest > 768 {
+		width, height = width*768/shortest, height*768/shortest
+	}
+
+	tiles := ((width + tileSize - 1) / tileSize) * ((height + tileSize - 1) / tileSize)
+
+	return tiles*tileTokens + baseTokens
+}
+
+// fit returns the width and height scaled down to fit within maxDimension.
+func fit(width, height, maxDimension int) (int, int) {
+	if width <= maxDimension && height <= maxDimension {
+		return width, height
+	}
+
+	if width >= height {
+		return maxDimension, max(1, height*maxDimension/width)
+	}
+
+	return max(1, width*maxDimension/height), maxDimension
+}
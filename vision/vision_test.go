@@ -0,0 +1,81 @@
+package vision
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestPrepare(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 400, 200))
+
+	for y := range 200 {
+		for x := range 400 {
+			src.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	img, err := Prepare(&buf, WithMaxDimension(100), WithQuality(50))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := img.MIMEType, "image/jpeg"; got != want {
+		t.Fatalf("img.MIMEType = %q, want %q", got, want)
+	}
+
+	if img.Width != 100 || img.Height != 50 {
+		t.Fatalf("size = %dx%d, want 100x50", img.Width, img.Height)
+	}
+
+	decoded, format, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := format, "jpeg"; got != want {
+		t.Fatalf("format = %q, want %q", got, want)
+	}
+
+	if r, g, _, _ := decoded.At(50, 25).RGBA(); r>>8 < 240 || g>>8 > 15 {
+		t.Fatalf("pixel is not red: r=%d g=%d", r>>8, g>>8)
+	}
+
+	if got, want := img.ImageURLContent(DetailLow).URL, "data:image/jpeg;base64,"; !strings.HasPrefix(got, want) {
+		t.Fatalf("URL = %q, want prefix %q", got, want)
+	}
+}
+
+func TestResizeKeepsSmallImages(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 10, 20))
+
+	if got := Resize(src, 20); got != image.Image(src) {
+		t.Fatalf("expected the same image to be returned")
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	for _, tt := range []struct {
+		width, height int
+		detail        string
+		want          int
+	}{
+		{4096, 4096, DetailLow, 85},
+		{1024, 1024, DetailHigh, 765},
+		{2048, 4096, DetailHigh, 1105},
+		{512, 512, DetailAuto, 255},
+	} {
+		if got := EstimateTokens(tt.width, tt.height, tt.detail); got != tt.want {
+			t.Fatalf("EstimateTokens(%d, %d, %q) = %d, want %d", tt.width, tt.height, tt.detail, got, tt.want)
+		}
+	}
+}